@@ -27,6 +27,7 @@ package gdk
 import "C"
 import (
 	"errors"
+	"github.com/dradtke/gotk3/gio"
 	"github.com/dradtke/gotk3/glib"
 	"runtime"
 	"unsafe"
@@ -79,6 +80,87 @@ const (
 	SELECTION_TYPE_STRING        = 31
 )
 
+// CURRENT_TIME represents the current time, for functions taking an event
+// timestamp.
+const CURRENT_TIME uint32 = C.GDK_CURRENT_TIME
+
+// ModifierType is a representation of GDK's GdkModifierType.
+type ModifierType uint
+
+const (
+	SHIFT_MASK    ModifierType = C.GDK_SHIFT_MASK
+	LOCK_MASK                  = C.GDK_LOCK_MASK
+	CONTROL_MASK               = C.GDK_CONTROL_MASK
+	MOD1_MASK                  = C.GDK_MOD1_MASK
+	MOD2_MASK                  = C.GDK_MOD2_MASK
+	MOD3_MASK                  = C.GDK_MOD3_MASK
+	MOD4_MASK                  = C.GDK_MOD4_MASK
+	MOD5_MASK                  = C.GDK_MOD5_MASK
+	BUTTON1_MASK               = C.GDK_BUTTON1_MASK
+	BUTTON2_MASK               = C.GDK_BUTTON2_MASK
+	BUTTON3_MASK               = C.GDK_BUTTON3_MASK
+	BUTTON4_MASK               = C.GDK_BUTTON4_MASK
+	BUTTON5_MASK               = C.GDK_BUTTON5_MASK
+	SUPER_MASK                 = C.GDK_SUPER_MASK
+	HYPER_MASK                 = C.GDK_HYPER_MASK
+	META_MASK                  = C.GDK_META_MASK
+	RELEASE_MASK               = C.GDK_RELEASE_MASK
+	MODIFIER_MASK              = C.GDK_MODIFIER_MASK
+)
+
+/*
+ * GdkAppLaunchContext
+ */
+
+// AppLaunchContext is a representation of GDK's GdkAppLaunchContext.  It
+// may be passed to any gio function taking a gio.IAppLaunchContext.
+type AppLaunchContext struct {
+	gio.AppLaunchContext
+}
+
+// Native() returns a pointer to the underlying GdkAppLaunchContext.
+func (v *AppLaunchContext) Native() *C.GdkAppLaunchContext {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	p := v.Ptr()
+	return C.toGdkAppLaunchContext(p)
+}
+
+// SetScreen() is a wrapper around gdk_app_launch_context_set_screen().
+func (v *AppLaunchContext) SetScreen(screen *Screen) {
+	C.gdk_app_launch_context_set_screen(v.Native(), screen.Native())
+}
+
+// SetDesktop() is a wrapper around gdk_app_launch_context_set_desktop().
+func (v *AppLaunchContext) SetDesktop(desktop int) {
+	C.gdk_app_launch_context_set_desktop(v.Native(), C.gint(desktop))
+}
+
+// SetTimestamp() is a wrapper around
+// gdk_app_launch_context_set_timestamp().
+func (v *AppLaunchContext) SetTimestamp(timestamp uint32) {
+	C.gdk_app_launch_context_set_timestamp(v.Native(), C.guint32(timestamp))
+}
+
+// SetIcon() is a wrapper around gdk_app_launch_context_set_icon().  icon
+// may be nil.
+func (v *AppLaunchContext) SetIcon(icon gio.IIcon) {
+	var p unsafe.Pointer
+	if icon != nil {
+		p = icon.ToObject().Ptr()
+	}
+	C.gdk_app_launch_context_set_icon(v.Native(), (*C.GIcon)(p))
+}
+
+// SetIconName() is a wrapper around
+// gdk_app_launch_context_set_icon_name().
+func (v *AppLaunchContext) SetIconName(iconName string) {
+	cstr := C.CString(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gdk_app_launch_context_set_icon_name(v.Native(), (*C.char)(cstr))
+}
+
 /*
  * GdkAtom
  */
@@ -91,6 +173,23 @@ func (v Atom) Native() C.GdkAtom {
 	return C.toGdkAtom(unsafe.Pointer(v))
 }
 
+/*
+ * Keyvals
+ */
+
+// KeyvalFromName() is a wrapper around gdk_keyval_from_name().
+func KeyvalFromName(keyvalName string) uint {
+	cstr := C.CString(keyvalName)
+	defer C.free(unsafe.Pointer(cstr))
+	return uint(C.gdk_keyval_from_name((*C.gchar)(cstr)))
+}
+
+// KeyvalName() is a wrapper around gdk_keyval_name().
+func KeyvalName(keyval uint) string {
+	c := C.gdk_keyval_name(C.guint(keyval))
+	return C.GoString((*C.char)(c))
+}
+
 /*
  * GdkDevice
  */
@@ -384,8 +483,17 @@ func (v *Display) SupportsComposite() bool {
 	return gobool(c)
 }
 
-// TODO(jrick) glib.AppLaunchContext GdkAppLaunchContext
-func (v *Display) GetAppLaunchContext() {
+// GetAppLaunchContext() is a wrapper around
+// gdk_display_get_app_launch_context().
+func (v *Display) GetAppLaunchContext() (*AppLaunchContext, error) {
+	c := C.gdk_display_get_app_launch_context(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	l := &AppLaunchContext{gio.AppLaunchContext{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return l, nil
 }
 
 // NotifyStartupComplete() is a wrapper around gdk_display_notify_startup_complete().
@@ -19,6 +19,12 @@
 #include <stdlib.h>
 
 // Type Casting
+static GdkAppLaunchContext *
+toGdkAppLaunchContext(void *p)
+{
+	return (GDK_APP_LAUNCH_CONTEXT(p));
+}
+
 static GdkAtom
 toGdkAtom(void *p)
 {
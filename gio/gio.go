@@ -5,9 +5,22 @@ package gio
 // #include "gio.go.h"
 import "C"
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"github.com/dradtke/gotk3/glib"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"reflect"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -29,11 +42,66 @@ func gobool(b C.gboolean) bool {
 	return false
 }
 
+// goStrv() converts a NULL-terminated array of C strings to a Go slice.
+func goStrv(c **C.gchar) []string {
+	var strs []string
+	if c == nil {
+		return strs
+	}
+	for p := c; *p != nil; p = (**C.gchar)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + unsafe.Sizeof(*p))) {
+		strs = append(strs, C.GoString((*C.char)(*p)))
+	}
+	return strs
+}
+
+// goError() converts a GError to a Go error and frees the GError.
+func goError(err *C.GError) error {
+	defer C.g_error_free(err)
+	return errors.New(C.GoString((*C.char)(C.error_get_message(err))))
+}
+
+// cStringOrNil() returns a C copy of s, or nil if s is empty.  A non-nil
+// result must be freed with C.free().
+func cStringOrNil(s string) *C.gchar {
+	if s == "" {
+		return nil
+	}
+	return (*C.gchar)(C.CString(s))
+}
+
+// goContextError() converts a GError from a cancellable operation to a
+// Go error and frees the GError.  If ctx is done, its error is returned
+// instead.
+func goContextError(ctx context.Context, err *C.GError) error {
+	if ctx.Err() != nil {
+		C.g_error_free(err)
+		return ctx.Err()
+	}
+	return goError(err)
+}
+
+// asyncReady() returns user data for _g_async_ready_callback() which
+// calls f with the result of an asynchronous operation.  f is called once,
+// from the main context that was the thread default when the operation
+// was started.
+func asyncReady(f func(result *C.GAsyncResult)) C.gpointer {
+	closure := glib.ClosureNew(func(_ *glib.Object, result *glib.Object) {
+		f((*C.GAsyncResult)(result.Ptr()))
+	})
+	return C._g_async_closure((*C.GClosure)(unsafe.Pointer(closure)))
+}
+
+// asyncReadyCallback is the GAsyncReadyCallback used with asyncReady().
+var asyncReadyCallback = C.GAsyncReadyCallback(C._g_async_ready_callback)
+
 /*
  * Unexported vars
  */
 
-var nilPtrErr = errors.New("cgo returned unexpected nil pointer")
+var (
+	nilPtrErr    = errors.New("cgo returned unexpected nil pointer")
+	emptyArgvErr = errors.New("argv must name a program to run")
+)
 
 /*
  * Constants
@@ -51,6 +119,17 @@ const (
 	NON_UNIQUE                            = C.G_APPLICATION_NON_UNIQUE
 )
 
+// Attributes and links used by menu models.
+const (
+	MENU_ATTRIBUTE_ACTION           string = "action"
+	MENU_ATTRIBUTE_ACTION_NAMESPACE        = "action-namespace"
+	MENU_ATTRIBUTE_TARGET                  = "target"
+	MENU_ATTRIBUTE_LABEL                   = "label"
+	MENU_ATTRIBUTE_ICON                    = "icon"
+	MENU_LINK_SECTION                      = "section"
+	MENU_LINK_SUBMENU                      = "submenu"
+)
+
 /*
  * Static methods
  */
@@ -67,11 +146,15 @@ func ApplicationIdIsValid(id string) bool {
  */
 
 type Application struct {
-	glib.Object
+	*glib.Object
+
+	// Interfaces
+	ActionGroup
+	ActionMap
 }
 
-func wrapApplication(obj *glib.Object) Application {
-	return Application{*obj}
+func wrapApplication(obj *glib.Object) *Application {
+	return &Application{obj, ActionGroup{obj}, ActionMap{obj}}
 }
 
 // Native() returns a pointer to the underlying GApplication.
@@ -93,7 +176,7 @@ func ApplicationNew(id string, flags ApplicationFlags) (*Application, error) {
 	obj := glib.ObjectNew(unsafe.Pointer(c))
 	a := wrapApplication(obj)
 	runtime.SetFinalizer(obj, (*glib.Object).Unref)
-	return &a, nil
+	return a, nil
 }
 
 // ApplicationId() is a wrapper around g_application_get_application_id().
@@ -150,18 +233,73 @@ func (v *Application) Run(args []string) int {
 	var c C.int
 	if args != nil {
 		argc := len(args)
-		argv := make([]*C.char, argc)
+		argv := make([]*C.char, argc+1)
 		for i, arg := range args {
 			argv[i] = C.CString(arg)
+			defer C.free(unsafe.Pointer(argv[i]))
 		}
 		c = C.g_application_run(v.Native(), C.int(argc),
-			(**C.char)(unsafe.Pointer(&argv)))
+			(**C.char)(unsafe.Pointer(&argv[0])))
 	} else {
 		c = C.g_application_run(v.Native(), 0, nil)
 	}
 	return int(c)
 }
 
+// Activate() is a wrapper around g_application_activate().
+func (v *Application) Activate() {
+	C.g_application_activate(v.Native())
+}
+
+// OnStartup() connects f to the "startup" signal, emitted on the primary
+// instance immediately after registration.
+func (v *Application) OnStartup(f func()) glib.SignalHandle {
+	return v.Connect("startup", func() {
+		f()
+	})
+}
+
+// OnShutdown() connects f to the "shutdown" signal, emitted on the
+// primary instance immediately before Run() returns.
+func (v *Application) OnShutdown(f func()) glib.SignalHandle {
+	return v.Connect("shutdown", func() {
+		f()
+	})
+}
+
+// OnActivate() connects f to the "activate" signal, emitted on the
+// primary instance when the application is run without files to open,
+// or when Activate() is called.
+func (v *Application) OnActivate(f func()) glib.SignalHandle {
+	return v.Connect("activate", func() {
+		f()
+	})
+}
+
+// OnOpen() connects f to the "open" signal, emitted on the primary
+// instance when files are passed to the application.  The application
+// must have the HANDLES_OPEN flag set.
+func (v *Application) OnOpen(f func(files []*File, hint string)) glib.SignalHandle {
+	return v.Connect("open", func(app *glib.Object, files unsafe.Pointer, nFiles int, hint string) {
+		f(fileArray(files, nFiles), hint)
+	})
+}
+
+// OnCommandLine() connects f to the "command-line" signal, emitted on the
+// primary instance when the application is run, including remotely from
+// another instance.  The value returned by f is used as the exit status
+// of the invoking process, unless the command line is still referenced
+// when f returns, in which case ApplicationCommandLine.SetExitStatus()
+// may be used instead.  The application must have the
+// HANDLES_COMMAND_LINE flag set.
+func (v *Application) OnCommandLine(f func(cmdline *ApplicationCommandLine) int) glib.SignalHandle {
+	return v.Connect("command-line", func(app *glib.Object, obj *glib.Object) int {
+		obj.Ref()
+		runtime.SetFinalizer(obj, (*glib.Object).Unref)
+		return f(wrapApplicationCommandLine(obj))
+	})
+}
+
 // Need at least GIO 2.38
 /*
 // MarkBusy() is a wrapper around g_application_mark_busy().
@@ -175,83 +313,6355 @@ func (v *Application) UnmarkBusy() {
 }
 */
 
+// Register() is a wrapper around g_application_register().  Run()
+// registers the application itself, so Register() is only needed to use
+// features such as notifications before the main loop is run.
+func (v *Application) Register(ctx context.Context) error {
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	var err *C.GError = nil
+	c := C.g_application_register(v.Native(), cancellable, &err)
+	if !gobool(c) {
+		return goContextError(ctx, err)
+	}
+	return nil
+}
+
+// GetIsRegistered() is a wrapper around g_application_get_is_registered().
+func (v *Application) GetIsRegistered() bool {
+	c := C.g_application_get_is_registered(v.Native())
+	return gobool(c)
+}
+
+// GetIsRemote() is a wrapper around g_application_get_is_remote().
+func (v *Application) GetIsRemote() bool {
+	c := C.g_application_get_is_remote(v.Native())
+	return gobool(c)
+}
+
+// GetDBusConnection() is a wrapper around
+// g_application_get_dbus_connection().  A non-nil error is returned if
+// the application is not registered on a message bus.
+func (v *Application) GetDBusConnection() (*DBusConnection, error) {
+	c := C.g_application_get_dbus_connection(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &DBusConnection{obj}, nil
+}
+
+// GetDBusObjectPath() is a wrapper around
+// g_application_get_dbus_object_path().
+func (v *Application) GetDBusObjectPath() string {
+	c := C.g_application_get_dbus_object_path(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// SendNotification() is a wrapper around
+// g_application_send_notification().  A notification sent with the same
+// id as an earlier one replaces it.  id may be empty, in which case the
+// notification cannot be withdrawn or replaced.  The application must be
+// registered.
+func (v *Application) SendNotification(id string, notification *Notification) {
+	cstr := cStringOrNil(id)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_application_send_notification(v.Native(), cstr,
+		notification.Native())
+}
+
+// WithdrawNotification() is a wrapper around
+// g_application_withdraw_notification().
+func (v *Application) WithdrawNotification(id string) {
+	cstr := C.CString(id)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_application_withdraw_notification(v.Native(), (*C.gchar)(cstr))
+}
+
 /*
- * DBus
+ * ApplicationCommandLine
  */
 
-type BusType int
+// ApplicationCommandLine is a representation of GIO's
+// GApplicationCommandLine.
+type ApplicationCommandLine struct {
+	*glib.Object
+}
 
-const (
-	BUS_TYPE_STARTER BusType = C.G_BUS_TYPE_STARTER
-	BUS_TYPE_NONE            = C.G_BUS_TYPE_NONE
-	BUS_TYPE_SYSTEM          = C.G_BUS_TYPE_SYSTEM
-	BUS_TYPE_SESSION         = C.G_BUS_TYPE_SESSION
-)
+func wrapApplicationCommandLine(obj *glib.Object) *ApplicationCommandLine {
+	return &ApplicationCommandLine{obj}
+}
 
-type BusNameOwnerFlags int
+// Native() returns a pointer to the underlying GApplicationCommandLine.
+func (v *ApplicationCommandLine) Native() *C.GApplicationCommandLine {
+	if v == nil || v.Ptr() == nil {
+		return nil
+	}
+	return (*C.GApplicationCommandLine)(v.Ptr())
+}
+
+// GetArguments() is a wrapper around
+// g_application_command_line_get_arguments().
+func (v *ApplicationCommandLine) GetArguments() []string {
+	c := C.g_application_command_line_get_arguments(v.Native(), nil)
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// GetCwd() is a wrapper around g_application_command_line_get_cwd().  A
+// non-nil error is returned in the case that the invoking process did
+// not report its working directory.
+func (v *ApplicationCommandLine) GetCwd() (string, error) {
+	c := C.g_application_command_line_get_cwd(v.Native())
+	if c == nil {
+		return "", nilPtrErr
+	}
+	return C.GoString((*C.char)(c)), nil
+}
+
+// GetEnviron() is a wrapper around
+// g_application_command_line_get_environ().  The environment of a remote
+// invocation is only available if the application has the
+// SEND_ENVIRONMENT flag set.
+func (v *ApplicationCommandLine) GetEnviron() []string {
+	c := C.g_application_command_line_get_environ(v.Native())
+	return goStrv((**C.gchar)(unsafe.Pointer(c)))
+}
+
+// Getenv() is a wrapper around g_application_command_line_getenv().
+func (v *ApplicationCommandLine) Getenv(name string) string {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_application_command_line_getenv(v.Native(), (*C.gchar)(cstr))
+	return C.GoString((*C.char)(c))
+}
+
+// GetIsRemote() is a wrapper around
+// g_application_command_line_get_is_remote().
+func (v *ApplicationCommandLine) GetIsRemote() bool {
+	c := C.g_application_command_line_get_is_remote(v.Native())
+	return gobool(c)
+}
+
+// GetStdin() is a wrapper around g_application_command_line_get_stdin().
+// A non-nil error is returned if stdin of the invoking process is not
+// available.
+func (v *ApplicationCommandLine) GetStdin() (*InputStream, error) {
+	c := C.g_application_command_line_get_stdin(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	s := wrapInputStream(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return s, nil
+}
+
+// GetExitStatus() is a wrapper around
+// g_application_command_line_get_exit_status().
+func (v *ApplicationCommandLine) GetExitStatus() int {
+	c := C.g_application_command_line_get_exit_status(v.Native())
+	return int(c)
+}
+
+// SetExitStatus() is a wrapper around
+// g_application_command_line_set_exit_status().
+func (v *ApplicationCommandLine) SetExitStatus(exitStatus int) {
+	C.g_application_command_line_set_exit_status(v.Native(),
+		C.int(exitStatus))
+}
+
+// Print() is a wrapper around g_application_command_line_print(), and
+// prints msg to stdout of the invoking process.
+func (v *ApplicationCommandLine) Print(msg string) {
+	cstr := C.CString(msg)
+	defer C.free(unsafe.Pointer(cstr))
+	C._g_application_command_line_print(v.Native(), (*C.gchar)(cstr))
+}
+
+// PrintErr() is a wrapper around g_application_command_line_printerr(),
+// and prints msg to stderr of the invoking process.
+func (v *ApplicationCommandLine) PrintErr(msg string) {
+	cstr := C.CString(msg)
+	defer C.free(unsafe.Pointer(cstr))
+	C._g_application_command_line_printerr(v.Native(), (*C.gchar)(cstr))
+}
+
+/*
+ * AppInfo
+ */
+
+type AppInfoCreateFlags int
 
 const (
-	BUS_NAME_OWNER_FLAGS_NONE              BusNameOwnerFlags = C.G_BUS_NAME_OWNER_FLAGS_NONE
-	BUS_NAME_OWNER_FLAGS_ALLOW_REPLACEMENT                   = C.G_BUS_NAME_OWNER_FLAGS_ALLOW_REPLACEMENT
-	BUS_NAME_OWNER_FLAGS_REPLACE                             = C.G_BUS_NAME_OWNER_FLAGS_REPLACE
+	APP_INFO_CREATE_NONE                          AppInfoCreateFlags = C.G_APP_INFO_CREATE_NONE
+	APP_INFO_CREATE_NEEDS_TERMINAL                                   = C.G_APP_INFO_CREATE_NEEDS_TERMINAL
+	APP_INFO_CREATE_SUPPORTS_URIS                                    = C.G_APP_INFO_CREATE_SUPPORTS_URIS
+	APP_INFO_CREATE_SUPPORTS_STARTUP_NOTIFICATION                    = C.G_APP_INFO_CREATE_SUPPORTS_STARTUP_NOTIFICATION
 )
 
-type BusCallback func(conn *DBusConnection, name string)
+// AppInfo is a representation of GIO's GAppInfo GInterface.
+type AppInfo struct {
+	*glib.Object
+}
 
-func (callback BusCallback) wrap() func(*glib.Object, string) {
-	return func(obj *glib.Object, name string) {
-		conn := wrapDBusConnection(obj)
-		callback(&conn, name)
+// Native() returns a pointer to the underlying GAppInfo.
+func (v *AppInfo) Native() *C.GAppInfo {
+	if v == nil || v.Object == nil {
+		return nil
 	}
+	return (*C.GAppInfo)(v.Ptr())
 }
 
-type BusNameHandle uint
+func appInfoFromNative(c *C.GAppInfo) (*AppInfo, error) {
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	info := &AppInfo{obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return info, nil
+}
 
-func (h BusNameHandle) Unown() {
-	C.g_bus_unown_name(C.guint(h))
+// appInfoList() converts a GList of GAppInfos, owned by the caller, to a
+// Go slice.
+func appInfoList(list *C.GList) []*AppInfo {
+	s := glib.ListToSlice(unsafe.Pointer(list), glib.TRANSFER_FULL)
+	infos := make([]*AppInfo, len(s))
+	for i, p := range s {
+		infos[i], _ = appInfoFromNative((*C.GAppInfo)(p))
+	}
+	return infos
 }
 
-// BusOwnName() is a wrapper around g_bus_own_name_with_closures(). One of the following three situations is guaranteed:
-//   * nameLost() is called with a null connection
-//   * busAcquired(), then nameLost() are called
-//   * busAcquired(), then nameAcquired() are called
-// For more information see https://developer.gnome.org/gio/stable/gio-Owning-Bus-Names.html#g-bus-own-name
-func BusOwnName(typ BusType, name string, flags BusNameOwnerFlags, busAcquired BusCallback, nameAcquired BusCallback, nameLost BusCallback) BusNameHandle {
-	cstr := C.CString(name)
+// AppInfoCreateFromCommandline() is a wrapper around
+// g_app_info_create_from_commandline().  appName may be empty, in which
+// case the name is taken from the command line.
+func AppInfoCreateFromCommandline(commandline, appName string, flags AppInfoCreateFlags) (*AppInfo, error) {
+	ccmd := C.CString(commandline)
+	defer C.free(unsafe.Pointer(ccmd))
+	cname := cStringOrNil(appName)
+	defer C.free(unsafe.Pointer(cname))
+	var err *C.GError = nil
+	c := C.g_app_info_create_from_commandline((*C.char)(ccmd),
+		(*C.char)(cname), C.GAppInfoCreateFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return appInfoFromNative(c)
+}
+
+// AppInfoGetDefaultForType() is a wrapper around
+// g_app_info_get_default_for_type().  A non-nil error is returned if no
+// application handles contentType.
+func AppInfoGetDefaultForType(contentType string, mustSupportURIs bool) (*AppInfo, error) {
+	cstr := C.CString(contentType)
 	defer C.free(unsafe.Pointer(cstr))
-	h := C.g_bus_own_name_with_closures(C.GBusType(typ), (*C.gchar)(cstr), C.GBusNameOwnerFlags(flags),
-		(*C.GClosure)(unsafe.Pointer(glib.ClosureNew(busAcquired.wrap()))),
-		(*C.GClosure)(unsafe.Pointer(glib.ClosureNew(nameAcquired.wrap()))),
-		(*C.GClosure)(unsafe.Pointer(glib.ClosureNew(nameLost.wrap()))))
-	return BusNameHandle(h)
+	return appInfoFromNative(C.g_app_info_get_default_for_type(
+		(*C.char)(cstr), gbool(mustSupportURIs)))
 }
 
-type DBusConnection struct {
-	glib.Object
+// AppInfoGetDefaultForURIScheme() is a wrapper around
+// g_app_info_get_default_for_uri_scheme().
+func AppInfoGetDefaultForURIScheme(uriScheme string) (*AppInfo, error) {
+	cstr := C.CString(uriScheme)
+	defer C.free(unsafe.Pointer(cstr))
+	return appInfoFromNative(C.g_app_info_get_default_for_uri_scheme(
+		(*C.char)(cstr)))
+}
+
+// AppInfoGetAll() is a wrapper around g_app_info_get_all().
+func AppInfoGetAll() []*AppInfo {
+	return appInfoList(C.g_app_info_get_all())
+}
+
+// AppInfoGetAllForType() is a wrapper around
+// g_app_info_get_all_for_type().
+func AppInfoGetAllForType(contentType string) []*AppInfo {
+	cstr := C.CString(contentType)
+	defer C.free(unsafe.Pointer(cstr))
+	return appInfoList(C.g_app_info_get_all_for_type((*C.char)(cstr)))
+}
+
+// AppInfoLaunchDefaultForURI() is a wrapper around
+// g_app_info_launch_default_for_uri().  launchContext may be nil.
+func AppInfoLaunchDefaultForURI(uri string, launchContext IAppLaunchContext) error {
+	cstr := C.CString(uri)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_app_info_launch_default_for_uri((*C.char)(cstr),
+		appLaunchContext(launchContext), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// GetId() is a wrapper around g_app_info_get_id().  An empty string is
+// returned for applications without a desktop file, such as those
+// created with AppInfoCreateFromCommandline().
+func (v *AppInfo) GetId() string {
+	c := C.g_app_info_get_id(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetName() is a wrapper around g_app_info_get_name().
+func (v *AppInfo) GetName() string {
+	c := C.g_app_info_get_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetDisplayName() is a wrapper around g_app_info_get_display_name().
+func (v *AppInfo) GetDisplayName() string {
+	c := C.g_app_info_get_display_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetDescription() is a wrapper around g_app_info_get_description().
+func (v *AppInfo) GetDescription() string {
+	c := C.g_app_info_get_description(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetExecutable() is a wrapper around g_app_info_get_executable().
+func (v *AppInfo) GetExecutable() string {
+	c := C.g_app_info_get_executable(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetCommandline() is a wrapper around g_app_info_get_commandline().
+func (v *AppInfo) GetCommandline() string {
+	c := C.g_app_info_get_commandline(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetIcon() is a wrapper around g_app_info_get_icon().
+func (v *AppInfo) GetIcon() (*Icon, error) {
+	c := C.g_app_info_get_icon(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	return iconFromNative(c, glib.TRANSFER_NONE), nil
+}
+
+// Equal() is a wrapper around g_app_info_equal().
+func (v *AppInfo) Equal(other *AppInfo) bool {
+	c := C.g_app_info_equal(v.Native(), other.Native())
+	return gobool(c)
+}
+
+// ShouldShow() is a wrapper around g_app_info_should_show().
+func (v *AppInfo) ShouldShow() bool {
+	c := C.g_app_info_should_show(v.Native())
+	return gobool(c)
+}
+
+// SupportsURIs() is a wrapper around g_app_info_supports_uris().
+func (v *AppInfo) SupportsURIs() bool {
+	c := C.g_app_info_supports_uris(v.Native())
+	return gobool(c)
+}
+
+// SupportsFiles() is a wrapper around g_app_info_supports_files().
+func (v *AppInfo) SupportsFiles() bool {
+	c := C.g_app_info_supports_files(v.Native())
+	return gobool(c)
+}
+
+// Launch() is a wrapper around g_app_info_launch().  launchContext may
+// be nil.
+func (v *AppInfo) Launch(files []*File, launchContext IAppLaunchContext) error {
+	var list *C.GList
+	for i := len(files) - 1; i >= 0; i-- {
+		list = C.g_list_prepend(list, C.gpointer(files[i].Native()))
+	}
+	defer C.g_list_free(list)
+	var err *C.GError = nil
+	c := C.g_app_info_launch(v.Native(), list,
+		appLaunchContext(launchContext), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// LaunchURIs() is a wrapper around g_app_info_launch_uris().
+// launchContext may be nil.
+func (v *AppInfo) LaunchURIs(uris []string, launchContext IAppLaunchContext) error {
+	var list *C.GList
+	for i := len(uris) - 1; i >= 0; i-- {
+		cstr := C.CString(uris[i])
+		defer C.free(unsafe.Pointer(cstr))
+		list = C.g_list_prepend(list, C.gpointer(cstr))
+	}
+	defer C.g_list_free(list)
+	var err *C.GError = nil
+	c := C.g_app_info_launch_uris(v.Native(), list,
+		appLaunchContext(launchContext), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
 }
 
-func wrapDBusConnection(obj *glib.Object) DBusConnection {
-	return DBusConnection{*obj}
+// ContentTypeGuess() is a wrapper around g_content_type_guess().  Either
+// filename or data may be empty.  The second return value reports
+// whether the guess is uncertain.
+func ContentTypeGuess(filename string, data []byte) (string, bool) {
+	cstr := cStringOrNil(filename)
+	defer C.free(unsafe.Pointer(cstr))
+	var p *C.guchar
+	if len(data) > 0 {
+		p = (*C.guchar)(unsafe.Pointer(&data[0]))
+	}
+	var uncertain C.gboolean
+	c := C.g_content_type_guess((*C.gchar)(cstr), p, C.gsize(len(data)),
+		&uncertain)
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), gobool(uncertain)
 }
 
 /*
- * Settings
+ * AppLaunchContext
  */
 
-type Settings struct {
-	ptr *C.GSettings
+// AppLaunchContext is a representation of GIO's GAppLaunchContext.
+type AppLaunchContext struct {
+	*glib.Object
 }
 
-func SettingsNew(schemaId string) (*Settings, error) {
-	cstr := C.CString(schemaId)
+// IAppLaunchContext is an interface type implemented by all structs
+// embedding an AppLaunchContext, such as gdk.AppLaunchContext.  It is
+// meant to be used as an argument type for wrapper functions that wrap
+// around a C function taking a GAppLaunchContext.
+type IAppLaunchContext interface {
+	glib.IObject
+	toAppLaunchContext() *C.GAppLaunchContext
+}
+
+// Native() returns a pointer to the underlying GAppLaunchContext.
+func (v *AppLaunchContext) Native() *C.GAppLaunchContext {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GAppLaunchContext)(v.Ptr())
+}
+
+func (v *AppLaunchContext) toAppLaunchContext() *C.GAppLaunchContext {
+	return v.Native()
+}
+
+// appLaunchContext() returns the GAppLaunchContext underlying
+// launchContext, or nil if launchContext is nil.
+func appLaunchContext(launchContext IAppLaunchContext) *C.GAppLaunchContext {
+	if launchContext == nil {
+		return nil
+	}
+	return launchContext.toAppLaunchContext()
+}
+
+// AppLaunchContextNew() is a wrapper around g_app_launch_context_new().
+func AppLaunchContextNew() (*AppLaunchContext, error) {
+	c := C.g_app_launch_context_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	l := &AppLaunchContext{obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return l, nil
+}
+
+// Setenv() is a wrapper around g_app_launch_context_setenv().
+func (v *AppLaunchContext) Setenv(variable, value string) {
+	cvar := C.CString(variable)
+	defer C.free(unsafe.Pointer(cvar))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	C.g_app_launch_context_setenv(v.Native(), (*C.char)(cvar),
+		(*C.char)(cvalue))
+}
+
+// Unsetenv() is a wrapper around g_app_launch_context_unsetenv().
+func (v *AppLaunchContext) Unsetenv(variable string) {
+	cstr := C.CString(variable)
 	defer C.free(unsafe.Pointer(cstr))
-	c := C.g_settings_new((*C.gchar)(cstr))
+	C.g_app_launch_context_unsetenv(v.Native(), (*C.char)(cstr))
+}
+
+// GetEnvironment() is a wrapper around
+// g_app_launch_context_get_environment().
+func (v *AppLaunchContext) GetEnvironment() []string {
+	c := C.g_app_launch_context_get_environment(v.Native())
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+/*
+ * Notification
+ */
+
+type NotificationPriority int
+
+const (
+	NOTIFICATION_PRIORITY_NORMAL NotificationPriority = C.G_NOTIFICATION_PRIORITY_NORMAL
+	NOTIFICATION_PRIORITY_LOW                         = C.G_NOTIFICATION_PRIORITY_LOW
+	NOTIFICATION_PRIORITY_HIGH                        = C.G_NOTIFICATION_PRIORITY_HIGH
+	NOTIFICATION_PRIORITY_URGENT                      = C.G_NOTIFICATION_PRIORITY_URGENT
+)
+
+// Notification is a representation of GIO's GNotification.  Actions
+// referenced by a notification must be in the "app." namespace, and are
+// activated on the Application which sent it.
+type Notification struct {
+	*glib.Object
+}
+
+// Native() returns a pointer to the underlying GNotification.
+func (v *Notification) Native() *C.GNotification {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GNotification)(v.Ptr())
+}
+
+// NotificationNew() is a wrapper around g_notification_new().
+func NotificationNew(title string) (*Notification, error) {
+	cstr := C.CString(title)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_notification_new((*C.gchar)(cstr))
 	if c == nil {
 		return nil, nilPtrErr
 	}
-	return &Settings{c}, nil
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	n := &Notification{obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return n, nil
 }
 
-func SettingsSync() {
-	C.g_settings_sync()
+// SetTitle() is a wrapper around g_notification_set_title().
+func (v *Notification) SetTitle(title string) {
+	cstr := C.CString(title)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_notification_set_title(v.Native(), (*C.gchar)(cstr))
+}
+
+// SetBody() is a wrapper around g_notification_set_body().
+func (v *Notification) SetBody(body string) {
+	cstr := cStringOrNil(body)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_notification_set_body(v.Native(), cstr)
+}
+
+// SetIcon() is a wrapper around g_notification_set_icon().
+func (v *Notification) SetIcon(notificationIcon IIcon) {
+	C.g_notification_set_icon(v.Native(), icon(notificationIcon))
+}
+
+// SetPriority() is a wrapper around g_notification_set_priority().
+func (v *Notification) SetPriority(priority NotificationPriority) {
+	C.g_notification_set_priority(v.Native(),
+		C.GNotificationPriority(priority))
+}
+
+// SetDefaultAction() is a wrapper around
+// g_notification_set_default_action().  detailedAction, such as
+// "app.open::inbox", is activated when the notification is clicked.
+func (v *Notification) SetDefaultAction(detailedAction string) {
+	cstr := C.CString(detailedAction)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_notification_set_default_action(v.Native(), (*C.gchar)(cstr))
+}
+
+// SetDefaultActionAndTarget() is a wrapper around
+// g_notification_set_default_action_and_target_value().  target may be
+// nil.
+func (v *Notification) SetDefaultActionAndTarget(action string, target *glib.Variant) {
+	cstr := C.CString(action)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_notification_set_default_action_and_target_value(v.Native(),
+		(*C.gchar)(cstr), variantPtr(target))
+}
+
+// AddButton() is a wrapper around g_notification_add_button().
+func (v *Notification) AddButton(label, detailedAction string) {
+	clabel := C.CString(label)
+	defer C.free(unsafe.Pointer(clabel))
+	caction := C.CString(detailedAction)
+	defer C.free(unsafe.Pointer(caction))
+	C.g_notification_add_button(v.Native(), (*C.gchar)(clabel),
+		(*C.gchar)(caction))
+}
+
+// AddButtonWithTarget() is a wrapper around
+// g_notification_add_button_with_target_value().  target may be nil.
+func (v *Notification) AddButtonWithTarget(label, action string, target *glib.Variant) {
+	clabel := C.CString(label)
+	defer C.free(unsafe.Pointer(clabel))
+	caction := C.CString(action)
+	defer C.free(unsafe.Pointer(caction))
+	C.g_notification_add_button_with_target_value(v.Native(),
+		(*C.gchar)(clabel), (*C.gchar)(caction), variantPtr(target))
+}
+
+/*
+ * Action
+ */
+
+// variantType() returns a new GVariantType parsed from a type string such
+// as "s" or "(ii)", or nil if typeString is empty.  A non-nil result must
+// be freed with g_variant_type_free().
+func variantType(typeString string) (*C.GVariantType, error) {
+	if typeString == "" {
+		return nil, nil
+	}
+	cstr := C.CString(typeString)
+	defer C.free(unsafe.Pointer(cstr))
+	if !gobool(C.g_variant_type_string_is_valid((*C.gchar)(cstr))) {
+		return nil, errors.New("invalid variant type string: " + typeString)
+	}
+	return C.g_variant_type_new((*C.gchar)(cstr)), nil
+}
+
+// variantTypeString() returns the type string of a GVariantType, or an
+// empty string if t is nil.
+func variantTypeString(t *C.GVariantType) string {
+	if t == nil {
+		return ""
+	}
+	c := C.g_variant_type_dup_string(t)
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// variantPtr() returns a pointer to the GVariant underlying v, or nil if
+// v is nil.
+func variantPtr(v *glib.Variant) *C.GVariant {
+	return (*C.GVariant)(v.Ptr())
+}
+
+// goVariant() wraps a GVariant returned with a full reference, or returns
+// nil if c is nil.
+func goVariant(c *C.GVariant) *glib.Variant {
+	if c == nil {
+		return nil
+	}
+	return glib.VariantFromPtr(unsafe.Pointer(c), glib.TRANSFER_FULL)
+}
+
+// Action is a representation of GIO's GAction GInterface.
+type Action struct {
+	*glib.Object
+}
+
+// IAction is an interface type implemented by all structs embedding an
+// Action.  It is meant to be used as an argument type for wrapper
+// functions that wrap around a C GIO function taking a GAction.
+type IAction interface {
+	toAction() *C.GAction
+}
+
+func wrapAction(obj *glib.Object) *Action {
+	return &Action{obj}
+}
+
+// Native() returns a pointer to the underlying GAction.
+func (v *Action) Native() *C.GAction {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GAction)(v.Ptr())
+}
+
+func (v *Action) toAction() *C.GAction {
+	return v.Native()
+}
+
+// GetName() is a wrapper around g_action_get_name().
+func (v *Action) GetName() string {
+	c := C.g_action_get_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetParameterType() is a wrapper around g_action_get_parameter_type().
+// The type is returned as a type string, which is empty if the action
+// takes no parameter.
+func (v *Action) GetParameterType() string {
+	c := C.g_action_get_parameter_type(v.Native())
+	return variantTypeString(c)
+}
+
+// GetStateType() is a wrapper around g_action_get_state_type().  The type
+// is returned as a type string, which is empty if the action is
+// stateless.
+func (v *Action) GetStateType() string {
+	c := C.g_action_get_state_type(v.Native())
+	return variantTypeString(c)
+}
+
+// GetStateHint() is a wrapper around g_action_get_state_hint().  nil is
+// returned if the action has no state hint.
+func (v *Action) GetStateHint() *glib.Variant {
+	c := C.g_action_get_state_hint(v.Native())
+	return goVariant(c)
+}
+
+// GetEnabled() is a wrapper around g_action_get_enabled().
+func (v *Action) GetEnabled() bool {
+	c := C.g_action_get_enabled(v.Native())
+	return gobool(c)
+}
+
+// GetState() is a wrapper around g_action_get_state().  nil is returned
+// if the action is stateless.
+func (v *Action) GetState() *glib.Variant {
+	c := C.g_action_get_state(v.Native())
+	return goVariant(c)
+}
+
+// ChangeState() is a wrapper around g_action_change_state().
+func (v *Action) ChangeState(value *glib.Variant) {
+	C.g_action_change_state(v.Native(), variantPtr(value))
+}
+
+// Activate() is a wrapper around g_action_activate().  parameter must be
+// nil if the action takes no parameter.
+func (v *Action) Activate(parameter *glib.Variant) {
+	C.g_action_activate(v.Native(), variantPtr(parameter))
+}
+
+/*
+ * ActionGroup
+ */
+
+// ActionGroup is a representation of GIO's GActionGroup GInterface.
+type ActionGroup struct {
+	*glib.Object
+}
+
+// IActionGroup is an interface type implemented by all structs embedding
+// an ActionGroup.  It is meant to be used as an argument type for wrapper
+// functions that wrap around a C function taking a GActionGroup.
+type IActionGroup interface {
+	glib.IObject
+	toActionGroup() *C.GActionGroup
+}
+
+// Native() returns a pointer to the underlying GActionGroup.
+func (v *ActionGroup) Native() *C.GActionGroup {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GActionGroup)(v.Ptr())
+}
+
+func (v *ActionGroup) toActionGroup() *C.GActionGroup {
+	return v.Native()
+}
+
+// ListActions() is a wrapper around g_action_group_list_actions().
+func (v *ActionGroup) ListActions() []string {
+	c := C.g_action_group_list_actions(v.Native())
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// HasAction() is a wrapper around g_action_group_has_action().
+func (v *ActionGroup) HasAction(actionName string) bool {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_action_group_has_action(v.Native(), (*C.gchar)(cstr))
+	return gobool(c)
+}
+
+// GetActionEnabled() is a wrapper around
+// g_action_group_get_action_enabled().
+func (v *ActionGroup) GetActionEnabled(actionName string) bool {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_action_group_get_action_enabled(v.Native(), (*C.gchar)(cstr))
+	return gobool(c)
+}
+
+// GetActionParameterType() is a wrapper around
+// g_action_group_get_action_parameter_type().  The type is returned as a
+// type string, which is empty if the action takes no parameter.
+func (v *ActionGroup) GetActionParameterType(actionName string) string {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_action_group_get_action_parameter_type(v.Native(),
+		(*C.gchar)(cstr))
+	return variantTypeString(c)
+}
+
+// GetActionState() is a wrapper around g_action_group_get_action_state().
+// nil is returned if the action is stateless.
+func (v *ActionGroup) GetActionState(actionName string) *glib.Variant {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_action_group_get_action_state(v.Native(), (*C.gchar)(cstr))
+	return goVariant(c)
+}
+
+// ActivateAction() is a wrapper around g_action_group_activate_action().
+func (v *ActionGroup) ActivateAction(actionName string, parameter *glib.Variant) {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_action_group_activate_action(v.Native(), (*C.gchar)(cstr),
+		variantPtr(parameter))
+}
+
+// ChangeActionState() is a wrapper around
+// g_action_group_change_action_state().
+func (v *ActionGroup) ChangeActionState(actionName string, value *glib.Variant) {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_action_group_change_action_state(v.Native(), (*C.gchar)(cstr),
+		variantPtr(value))
+}
+
+/*
+ * ActionMap
+ */
+
+// ActionMap is a representation of GIO's GActionMap GInterface.
+type ActionMap struct {
+	*glib.Object
+}
+
+// Native() returns a pointer to the underlying GActionMap.
+func (v *ActionMap) Native() *C.GActionMap {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GActionMap)(v.Ptr())
+}
+
+// AddAction() is a wrapper around g_action_map_add_action().
+func (v *ActionMap) AddAction(action IAction) {
+	C.g_action_map_add_action(v.Native(), action.toAction())
+}
+
+// RemoveAction() is a wrapper around g_action_map_remove_action().
+func (v *ActionMap) RemoveAction(actionName string) {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_action_map_remove_action(v.Native(), (*C.gchar)(cstr))
+}
+
+// LookupAction() is a wrapper around g_action_map_lookup_action().
+func (v *ActionMap) LookupAction(actionName string) (*Action, error) {
+	cstr := C.CString(actionName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_action_map_lookup_action(v.Native(), (*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := wrapAction(obj)
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return a, nil
+}
+
+/*
+ * SimpleAction
+ */
+
+// SimpleAction is a representation of GIO's GSimpleAction.
+type SimpleAction struct {
+	Action
+}
+
+func wrapSimpleAction(obj *glib.Object) *SimpleAction {
+	return &SimpleAction{Action{obj}}
+}
+
+// Native() returns a pointer to the underlying GSimpleAction.
+func (v *SimpleAction) Native() *C.GSimpleAction {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSimpleAction)(v.Ptr())
+}
+
+// SimpleActionNew() is a wrapper around g_simple_action_new().
+// parameterType is a variant type string, such as "s", or an empty
+// string if the action takes no parameter.
+func SimpleActionNew(name, parameterType string) (*SimpleAction, error) {
+	t, err := variantType(parameterType)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		defer C.g_variant_type_free(t)
+	}
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_simple_action_new((*C.gchar)(cstr), t)
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := wrapSimpleAction(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return a, nil
+}
+
+// SimpleActionNewStateful() is a wrapper around
+// g_simple_action_new_stateful().  The type of the action's state is the
+// type of state.
+func SimpleActionNewStateful(name, parameterType string, state *glib.Variant) (*SimpleAction, error) {
+	t, err := variantType(parameterType)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		defer C.g_variant_type_free(t)
+	}
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_simple_action_new_stateful((*C.gchar)(cstr), t,
+		variantPtr(state))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := wrapSimpleAction(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return a, nil
+}
+
+// SetEnabled() is a wrapper around g_simple_action_set_enabled().
+func (v *SimpleAction) SetEnabled(enabled bool) {
+	C.g_simple_action_set_enabled(v.Native(), gbool(enabled))
+}
+
+// SetState() is a wrapper around g_simple_action_set_state().
+func (v *SimpleAction) SetState(value *glib.Variant) {
+	C.g_simple_action_set_state(v.Native(), variantPtr(value))
+}
+
+// OnActivate() connects f to the action's "activate" signal.  parameter
+// is nil if the action takes no parameter.
+func (v *SimpleAction) OnActivate(f func(action *SimpleAction, parameter *glib.Variant)) glib.SignalHandle {
+	return v.Connect("activate", func(_ *glib.Object, parameter *glib.Variant) {
+		f(v, parameter)
+	})
+}
+
+// OnChangeState() connects f to the action's "change-state" signal.  Once
+// a handler is connected, state changes requested with ChangeState() are
+// no longer applied automatically; f should call SetState() to accept
+// the new value.
+func (v *SimpleAction) OnChangeState(f func(action *SimpleAction, value *glib.Variant)) glib.SignalHandle {
+	return v.Connect("change-state", func(_ *glib.Object, value *glib.Variant) {
+		f(v, value)
+	})
+}
+
+/*
+ * SimpleActionGroup
+ */
+
+// SimpleActionGroup is a representation of GIO's GSimpleActionGroup.
+type SimpleActionGroup struct {
+	*glib.Object
+
+	// Interfaces
+	ActionGroup
+	ActionMap
+}
+
+func wrapSimpleActionGroup(obj *glib.Object) *SimpleActionGroup {
+	return &SimpleActionGroup{obj, ActionGroup{obj}, ActionMap{obj}}
+}
+
+// Native() returns a pointer to the underlying GSimpleActionGroup.
+func (v *SimpleActionGroup) Native() *C.GSimpleActionGroup {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSimpleActionGroup)(v.Ptr())
+}
+
+// SimpleActionGroupNew() is a wrapper around g_simple_action_group_new().
+func SimpleActionGroupNew() (*SimpleActionGroup, error) {
+	c := C.g_simple_action_group_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	g := wrapSimpleActionGroup(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return g, nil
+}
+
+/*
+ * Cancellable
+ */
+
+// Cancellable is a representation of GIO's GCancellable.
+type Cancellable struct {
+	*glib.Object
+}
+
+func wrapCancellable(obj *glib.Object) *Cancellable {
+	return &Cancellable{obj}
+}
+
+// Native() returns a pointer to the underlying GCancellable.
+func (v *Cancellable) Native() *C.GCancellable {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GCancellable)(v.Ptr())
+}
+
+// CancellableNew() is a wrapper around g_cancellable_new().
+func CancellableNew() (*Cancellable, error) {
+	c := C.g_cancellable_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	cancellable := wrapCancellable(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return cancellable, nil
+}
+
+// CancellableFromContext() returns a new Cancellable which is cancelled
+// when ctx is done, and a function which must be called to release it
+// once the operation using it has finished.  A nil Cancellable, which
+// may be passed wherever a Cancellable is accepted, is returned if ctx
+// can never be cancelled.
+func CancellableFromContext(ctx context.Context) (*Cancellable, func()) {
+	if ctx.Done() == nil {
+		return nil, func() {}
+	}
+	cancellable, err := CancellableNew()
+	if err != nil {
+		return nil, func() {}
+	}
+	if ctx.Err() != nil {
+		cancellable.Cancel()
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			cancellable.Cancel()
+		case <-stop:
+		}
+	}()
+	return cancellable, func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+// cancellableFromContext() is like CancellableFromContext(), but returns
+// the underlying GCancellable.  The GCancellable remains valid until the
+// release function is called.
+func cancellableFromContext(ctx context.Context) (*C.GCancellable, func()) {
+	cancellable, release := CancellableFromContext(ctx)
+	return cancellable.Native(), func() {
+		release()
+		runtime.KeepAlive(cancellable)
+	}
+}
+
+// Cancel() is a wrapper around g_cancellable_cancel().  It is safe to
+// call from any goroutine.
+func (v *Cancellable) Cancel() {
+	C.g_cancellable_cancel(v.Native())
+}
+
+// IsCancelled() is a wrapper around g_cancellable_is_cancelled().
+func (v *Cancellable) IsCancelled() bool {
+	c := C.g_cancellable_is_cancelled(v.Native())
+	return gobool(c)
+}
+
+// Reset() is a wrapper around g_cancellable_reset().
+func (v *Cancellable) Reset() {
+	C.g_cancellable_reset(v.Native())
+}
+
+// Connect() is a wrapper around g_cancellable_connect().  f is called
+// once the Cancellable is cancelled, from the goroutine which cancelled
+// it, or immediately if it has already been cancelled.  The returned id
+// may be passed to Disconnect(); 0 is returned if f was called
+// immediately.  Connect() shadows (*glib.Object).Connect(), which
+// remains available through the embedded Object.
+func (v *Cancellable) Connect(f func()) uint {
+	closure := glib.ClosureNew(f)
+	c := C._g_cancellable_connect(v.Native(),
+		(*C.GClosure)(unsafe.Pointer(closure)))
+	return uint(c)
+}
+
+// Disconnect() is a wrapper around g_cancellable_disconnect().
+func (v *Cancellable) Disconnect(id uint) {
+	C.g_cancellable_disconnect(v.Native(), C.gulong(id))
+}
+
+/*
+ * File
+ */
+
+type FileType int
+
+const (
+	FILE_TYPE_UNKNOWN       FileType = C.G_FILE_TYPE_UNKNOWN
+	FILE_TYPE_REGULAR                = C.G_FILE_TYPE_REGULAR
+	FILE_TYPE_DIRECTORY              = C.G_FILE_TYPE_DIRECTORY
+	FILE_TYPE_SYMBOLIC_LINK          = C.G_FILE_TYPE_SYMBOLIC_LINK
+	FILE_TYPE_SPECIAL                = C.G_FILE_TYPE_SPECIAL
+	FILE_TYPE_SHORTCUT               = C.G_FILE_TYPE_SHORTCUT
+	FILE_TYPE_MOUNTABLE              = C.G_FILE_TYPE_MOUNTABLE
+)
+
+type FileCreateFlags int
+
+const (
+	FILE_CREATE_NONE                FileCreateFlags = C.G_FILE_CREATE_NONE
+	FILE_CREATE_PRIVATE                             = C.G_FILE_CREATE_PRIVATE
+	FILE_CREATE_REPLACE_DESTINATION                 = C.G_FILE_CREATE_REPLACE_DESTINATION
+)
+
+type FileCopyFlags int
+
+const (
+	FILE_COPY_NONE                 FileCopyFlags = C.G_FILE_COPY_NONE
+	FILE_COPY_OVERWRITE                          = C.G_FILE_COPY_OVERWRITE
+	FILE_COPY_BACKUP                             = C.G_FILE_COPY_BACKUP
+	FILE_COPY_NOFOLLOW_SYMLINKS                  = C.G_FILE_COPY_NOFOLLOW_SYMLINKS
+	FILE_COPY_ALL_METADATA                       = C.G_FILE_COPY_ALL_METADATA
+	FILE_COPY_NO_FALLBACK_FOR_MOVE               = C.G_FILE_COPY_NO_FALLBACK_FOR_MOVE
+	FILE_COPY_TARGET_DEFAULT_PERMS               = C.G_FILE_COPY_TARGET_DEFAULT_PERMS
+)
+
+type FileQueryInfoFlags int
+
+const (
+	FILE_QUERY_INFO_NONE              FileQueryInfoFlags = C.G_FILE_QUERY_INFO_NONE
+	FILE_QUERY_INFO_NOFOLLOW_SYMLINKS                    = C.G_FILE_QUERY_INFO_NOFOLLOW_SYMLINKS
+)
+
+// File is a representation of GIO's GFile GInterface.
+type File struct {
+	*glib.Object
+}
+
+func wrapFile(obj *glib.Object) *File {
+	return &File{obj}
+}
+
+// Native() returns a pointer to the underlying GFile.
+func (v *File) Native() *C.GFile {
+	if v == nil || v.Ptr() == nil {
+		return nil
+	}
+	return (*C.GFile)(v.Ptr())
+}
+
+// fileFromNative() wraps a GFile returned with a full reference.
+func fileFromNative(c *C.GFile) (*File, error) {
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	f := wrapFile(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return f, nil
+}
+
+// fileArray() converts a C array of n GFiles, owned by the caller, to a
+// Go slice.
+func fileArray(files unsafe.Pointer, n int) []*File {
+	s := make([]*File, n)
+	for i := range s {
+		c := *(**C.GFile)(unsafe.Pointer(uintptr(files) + uintptr(i)*unsafe.Sizeof((*C.GFile)(nil))))
+		obj := glib.ObjectNew(unsafe.Pointer(c))
+		s[i] = wrapFile(obj)
+		obj.Ref()
+		runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	}
+	return s
+}
+
+// FileNewForPath() is a wrapper around g_file_new_for_path().
+func FileNewForPath(path string) (*File, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	return fileFromNative(C.g_file_new_for_path((*C.char)(cstr)))
+}
+
+// FileNewForURI() is a wrapper around g_file_new_for_uri().
+func FileNewForURI(uri string) (*File, error) {
+	cstr := C.CString(uri)
+	defer C.free(unsafe.Pointer(cstr))
+	return fileFromNative(C.g_file_new_for_uri((*C.char)(cstr)))
+}
+
+// FileNewForCommandlineArg() is a wrapper around
+// g_file_new_for_commandline_arg().
+func FileNewForCommandlineArg(arg string) (*File, error) {
+	cstr := C.CString(arg)
+	defer C.free(unsafe.Pointer(cstr))
+	return fileFromNative(C.g_file_new_for_commandline_arg((*C.char)(cstr)))
+}
+
+// GetPath() is a wrapper around g_file_get_path().  A non-nil error is
+// returned in the case that the file has no local path.
+func (v *File) GetPath() (string, error) {
+	c := C.g_file_get_path(v.Native())
+	if c == nil {
+		return "", nilPtrErr
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString(c), nil
+}
+
+// GetURI() is a wrapper around g_file_get_uri().
+func (v *File) GetURI() string {
+	c := C.g_file_get_uri(v.Native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString(c)
+}
+
+// GetBasename() is a wrapper around g_file_get_basename().
+func (v *File) GetBasename() string {
+	c := C.g_file_get_basename(v.Native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString(c)
+}
+
+// GetParseName() is a wrapper around g_file_get_parse_name().
+func (v *File) GetParseName() string {
+	c := C.g_file_get_parse_name(v.Native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString(c)
+}
+
+// GetParent() is a wrapper around g_file_get_parent().  A non-nil error
+// is returned if the file is the root of its filesystem.
+func (v *File) GetParent() (*File, error) {
+	return fileFromNative(C.g_file_get_parent(v.Native()))
+}
+
+// GetChild() is a wrapper around g_file_get_child().
+func (v *File) GetChild(name string) (*File, error) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	return fileFromNative(C.g_file_get_child(v.Native(), (*C.char)(cstr)))
+}
+
+// Equal() is a wrapper around g_file_equal().
+func (v *File) Equal(file *File) bool {
+	c := C.g_file_equal(v.Native(), file.Native())
+	return gobool(c)
+}
+
+// QueryExists() is a wrapper around g_file_query_exists().
+func (v *File) QueryExists() bool {
+	c := C.g_file_query_exists(v.Native(), nil)
+	return gobool(c)
+}
+
+// QueryFileType() is a wrapper around g_file_query_file_type().
+func (v *File) QueryFileType(flags FileQueryInfoFlags) FileType {
+	c := C.g_file_query_file_type(v.Native(),
+		C.GFileQueryInfoFlags(flags), nil)
+	return FileType(c)
+}
+
+// Read() is a wrapper around g_file_read().
+func (v *File) Read() (*FileInputStream, error) {
+	var err *C.GError = nil
+	c := C.g_file_read(v.Native(), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileInputStreamFromNative(c), nil
+}
+
+// Create() is a wrapper around g_file_create().  An error is returned if
+// the file already exists.
+func (v *File) Create(flags FileCreateFlags) (*FileOutputStream, error) {
+	var err *C.GError = nil
+	c := C.g_file_create(v.Native(), C.GFileCreateFlags(flags), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileOutputStreamFromNative(c), nil
+}
+
+// AppendTo() is a wrapper around g_file_append_to().
+func (v *File) AppendTo(flags FileCreateFlags) (*FileOutputStream, error) {
+	var err *C.GError = nil
+	c := C.g_file_append_to(v.Native(), C.GFileCreateFlags(flags), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileOutputStreamFromNative(c), nil
+}
+
+// Replace() is a wrapper around g_file_replace().  The file is replaced
+// atomically once the returned stream is closed.  etag may be empty.
+func (v *File) Replace(etag string, makeBackup bool, flags FileCreateFlags) (*FileOutputStream, error) {
+	cetag := cStringOrNil(etag)
+	defer C.free(unsafe.Pointer(cetag))
+	var err *C.GError = nil
+	c := C.g_file_replace(v.Native(), (*C.char)(cetag), gbool(makeBackup),
+		C.GFileCreateFlags(flags), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileOutputStreamFromNative(c), nil
+}
+
+// LoadContents() is a wrapper around g_file_load_contents().
+func (v *File) LoadContents() ([]byte, error) {
+	var (
+		contents *C.char
+		length   C.gsize
+		err      *C.GError = nil
+	)
+	c := C.g_file_load_contents(v.Native(), nil, &contents, &length, nil,
+		&err)
+	if !gobool(c) {
+		return nil, goError(err)
+	}
+	defer C.g_free(C.gpointer(contents))
+	return C.GoBytes(unsafe.Pointer(contents), C.int(length)), nil
+}
+
+// ReplaceContents() is a wrapper around g_file_replace_contents().  etag
+// may be empty.
+func (v *File) ReplaceContents(contents []byte, etag string, makeBackup bool, flags FileCreateFlags) error {
+	var p *C.char
+	if len(contents) > 0 {
+		p = (*C.char)(unsafe.Pointer(&contents[0]))
+	}
+	cetag := cStringOrNil(etag)
+	defer C.free(unsafe.Pointer(cetag))
+	var err *C.GError = nil
+	c := C.g_file_replace_contents(v.Native(), p, C.gsize(len(contents)),
+		(*C.char)(cetag), gbool(makeBackup), C.GFileCreateFlags(flags), nil,
+		nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// QueryInfo() is a wrapper around g_file_query_info().  attributes is a
+// comma-separated list of attributes or namespaces to query, such as
+// "standard::*,time::modified".
+func (v *File) QueryInfo(attributes string, flags FileQueryInfoFlags) (*FileInfo, error) {
+	cstr := C.CString(attributes)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_file_query_info(v.Native(), (*C.char)(cstr),
+		C.GFileQueryInfoFlags(flags), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileInfoFromNative(c), nil
+}
+
+// EnumerateChildren() is a wrapper around g_file_enumerate_children().
+// attributes is as for QueryInfo().
+func (v *File) EnumerateChildren(attributes string, flags FileQueryInfoFlags) (*FileEnumerator, error) {
+	cstr := C.CString(attributes)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_file_enumerate_children(v.Native(), (*C.char)(cstr),
+		C.GFileQueryInfoFlags(flags), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileEnumeratorFromNative(c), nil
+}
+
+// Copy() is a wrapper around g_file_copy().
+func (v *File) Copy(destination *File, flags FileCopyFlags) error {
+	var err *C.GError = nil
+	c := C.g_file_copy(v.Native(), destination.Native(),
+		C.GFileCopyFlags(flags), nil, nil, nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// Move() is a wrapper around g_file_move().
+func (v *File) Move(destination *File, flags FileCopyFlags) error {
+	var err *C.GError = nil
+	c := C.g_file_move(v.Native(), destination.Native(),
+		C.GFileCopyFlags(flags), nil, nil, nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// Delete() is a wrapper around g_file_delete().
+func (v *File) Delete() error {
+	var err *C.GError = nil
+	c := C.g_file_delete(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// Trash() is a wrapper around g_file_trash().
+func (v *File) Trash() error {
+	var err *C.GError = nil
+	c := C.g_file_trash(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// MakeDirectory() is a wrapper around g_file_make_directory().
+func (v *File) MakeDirectory() error {
+	var err *C.GError = nil
+	c := C.g_file_make_directory(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// MakeDirectoryWithParents() is a wrapper around
+// g_file_make_directory_with_parents().
+func (v *File) MakeDirectoryWithParents() error {
+	var err *C.GError = nil
+	c := C.g_file_make_directory_with_parents(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// ReadAsync() is a wrapper around g_file_read_async().  f is called from
+// the main loop once the file is opened, the operation fails, or ctx is
+// done.
+func (v *File) ReadAsync(ctx context.Context, f func(stream *FileInputStream, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_file_read_async(v.Native(), C.G_PRIORITY_DEFAULT, cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_read_finish(v.Native(), result, &err)
+			if c == nil {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			f(fileInputStreamFromNative(c), nil)
+		}))
+}
+
+// ReplaceAsync() is a wrapper around g_file_replace_async().  f is called
+// as for ReadAsync().
+func (v *File) ReplaceAsync(ctx context.Context, etag string, makeBackup bool, flags FileCreateFlags, f func(stream *FileOutputStream, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	cetag := cStringOrNil(etag)
+	defer C.free(unsafe.Pointer(cetag))
+	C.g_file_replace_async(v.Native(), (*C.char)(cetag), gbool(makeBackup),
+		C.GFileCreateFlags(flags), C.G_PRIORITY_DEFAULT, cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_replace_finish(v.Native(), result, &err)
+			if c == nil {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			f(fileOutputStreamFromNative(c), nil)
+		}))
+}
+
+// LoadContentsAsync() is a wrapper around g_file_load_contents_async().
+// f is called as for ReadAsync().
+func (v *File) LoadContentsAsync(ctx context.Context, f func(contents []byte, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_file_load_contents_async(v.Native(), cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var (
+				contents *C.char
+				length   C.gsize
+				err      *C.GError = nil
+			)
+			c := C.g_file_load_contents_finish(v.Native(), result,
+				&contents, &length, nil, &err)
+			if !gobool(c) {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			defer C.g_free(C.gpointer(contents))
+			f(C.GoBytes(unsafe.Pointer(contents), C.int(length)), nil)
+		}))
+}
+
+// QueryInfoAsync() is a wrapper around g_file_query_info_async().  f is
+// called as for ReadAsync().
+func (v *File) QueryInfoAsync(ctx context.Context, attributes string, flags FileQueryInfoFlags, f func(info *FileInfo, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	cstr := C.CString(attributes)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_file_query_info_async(v.Native(), (*C.char)(cstr),
+		C.GFileQueryInfoFlags(flags), C.G_PRIORITY_DEFAULT, cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_query_info_finish(v.Native(), result, &err)
+			if c == nil {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			f(fileInfoFromNative(c), nil)
+		}))
+}
+
+// EnumerateChildrenAsync() is a wrapper around
+// g_file_enumerate_children_async().  f is called as for ReadAsync().
+func (v *File) EnumerateChildrenAsync(ctx context.Context, attributes string, flags FileQueryInfoFlags, f func(enumerator *FileEnumerator, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	cstr := C.CString(attributes)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_file_enumerate_children_async(v.Native(), (*C.char)(cstr),
+		C.GFileQueryInfoFlags(flags), C.G_PRIORITY_DEFAULT, cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_enumerate_children_finish(v.Native(), result, &err)
+			if c == nil {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			f(fileEnumeratorFromNative(c), nil)
+		}))
+}
+
+// CopyAsync() is a wrapper around g_file_copy_async().  f is called as for
+// ReadAsync().
+func (v *File) CopyAsync(ctx context.Context, destination *File, flags FileCopyFlags, f func(err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_file_copy_async(v.Native(), destination.Native(),
+		C.GFileCopyFlags(flags), C.G_PRIORITY_DEFAULT, cancellable, nil, nil,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_copy_finish(v.Native(), result, &err)
+			if !gobool(c) {
+				f(goContextError(ctx, err))
+				return
+			}
+			f(nil)
+		}))
+}
+
+// DeleteAsync() is a wrapper around g_file_delete_async().  f is called
+// as for ReadAsync().
+func (v *File) DeleteAsync(ctx context.Context, f func(err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_file_delete_async(v.Native(), C.G_PRIORITY_DEFAULT, cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_delete_finish(v.Native(), result, &err)
+			if !gobool(c) {
+				f(goContextError(ctx, err))
+				return
+			}
+			f(nil)
+		}))
+}
+
+// MakeDirectoryAsync() is a wrapper around g_file_make_directory_async().
+// f is called as for ReadAsync().
+func (v *File) MakeDirectoryAsync(ctx context.Context, f func(err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_file_make_directory_async(v.Native(), C.G_PRIORITY_DEFAULT,
+		cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_file_make_directory_finish(v.Native(), result, &err)
+			if !gobool(c) {
+				f(goContextError(ctx, err))
+				return
+			}
+			f(nil)
+		}))
+}
+
+// Monitor() is a wrapper around g_file_monitor().
+func (v *File) Monitor(flags FileMonitorFlags) (*FileMonitor, error) {
+	var err *C.GError = nil
+	c := C.g_file_monitor(v.Native(), C.GFileMonitorFlags(flags), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileMonitorFromNative(c), nil
+}
+
+// MonitorDirectory() is a wrapper around g_file_monitor_directory().
+func (v *File) MonitorDirectory(flags FileMonitorFlags) (*FileMonitor, error) {
+	var err *C.GError = nil
+	c := C.g_file_monitor_directory(v.Native(), C.GFileMonitorFlags(flags),
+		nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileMonitorFromNative(c), nil
+}
+
+// MonitorFile() is a wrapper around g_file_monitor_file().
+func (v *File) MonitorFile(flags FileMonitorFlags) (*FileMonitor, error) {
+	var err *C.GError = nil
+	c := C.g_file_monitor_file(v.Native(), C.GFileMonitorFlags(flags), nil,
+		&err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return fileMonitorFromNative(c), nil
+}
+
+/*
+ * FileMonitor
+ */
+
+type FileMonitorFlags int
+
+const (
+	FILE_MONITOR_NONE             FileMonitorFlags = C.G_FILE_MONITOR_NONE
+	FILE_MONITOR_WATCH_MOUNTS                      = C.G_FILE_MONITOR_WATCH_MOUNTS
+	FILE_MONITOR_SEND_MOVED                        = C.G_FILE_MONITOR_SEND_MOVED
+	FILE_MONITOR_WATCH_HARD_LINKS                  = C.G_FILE_MONITOR_WATCH_HARD_LINKS
+	FILE_MONITOR_WATCH_MOVES                       = C.G_FILE_MONITOR_WATCH_MOVES
+)
+
+type FileMonitorEvent int
+
+const (
+	FILE_MONITOR_EVENT_CHANGED           FileMonitorEvent = C.G_FILE_MONITOR_EVENT_CHANGED
+	FILE_MONITOR_EVENT_CHANGES_DONE_HINT                  = C.G_FILE_MONITOR_EVENT_CHANGES_DONE_HINT
+	FILE_MONITOR_EVENT_DELETED                            = C.G_FILE_MONITOR_EVENT_DELETED
+	FILE_MONITOR_EVENT_CREATED                            = C.G_FILE_MONITOR_EVENT_CREATED
+	FILE_MONITOR_EVENT_ATTRIBUTE_CHANGED                  = C.G_FILE_MONITOR_EVENT_ATTRIBUTE_CHANGED
+	FILE_MONITOR_EVENT_PRE_UNMOUNT                        = C.G_FILE_MONITOR_EVENT_PRE_UNMOUNT
+	FILE_MONITOR_EVENT_UNMOUNTED                          = C.G_FILE_MONITOR_EVENT_UNMOUNTED
+	FILE_MONITOR_EVENT_MOVED                              = C.G_FILE_MONITOR_EVENT_MOVED
+	FILE_MONITOR_EVENT_RENAMED                            = C.G_FILE_MONITOR_EVENT_RENAMED
+	FILE_MONITOR_EVENT_MOVED_IN                           = C.G_FILE_MONITOR_EVENT_MOVED_IN
+	FILE_MONITOR_EVENT_MOVED_OUT                          = C.G_FILE_MONITOR_EVENT_MOVED_OUT
+)
+
+// FileMonitor is a representation of GIO's GFileMonitor.  A monitor only
+// delivers events while a reference to it is held, so it must be kept
+// reachable for as long as changes should be reported.
+type FileMonitor struct {
+	*glib.Object
+}
+
+func wrapFileMonitor(obj *glib.Object) *FileMonitor {
+	return &FileMonitor{obj}
+}
+
+func fileMonitorFromNative(c *C.GFileMonitor) *FileMonitor {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapFileMonitor(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m
+}
+
+// Native() returns a pointer to the underlying GFileMonitor.
+func (v *FileMonitor) Native() *C.GFileMonitor {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GFileMonitor)(v.Ptr())
+}
+
+// Cancel() is a wrapper around g_file_monitor_cancel().
+func (v *FileMonitor) Cancel() bool {
+	c := C.g_file_monitor_cancel(v.Native())
+	return gobool(c)
+}
+
+// IsCancelled() is a wrapper around g_file_monitor_is_cancelled().
+func (v *FileMonitor) IsCancelled() bool {
+	c := C.g_file_monitor_is_cancelled(v.Native())
+	return gobool(c)
+}
+
+// SetRateLimit() is a wrapper around g_file_monitor_set_rate_limit().
+// limitMsecs is the minimum time between change events for a file, in
+// milliseconds.
+func (v *FileMonitor) SetRateLimit(limitMsecs int) {
+	C.g_file_monitor_set_rate_limit(v.Native(), C.gint(limitMsecs))
+}
+
+// OnChanged() connects f to the monitor's "changed" signal.  otherFile is
+// nil unless the event involves a second file, such as the destination of
+// FILE_MONITOR_EVENT_MOVED or FILE_MONITOR_EVENT_RENAMED.
+func (v *FileMonitor) OnChanged(f func(monitor *FileMonitor, file, otherFile *File, event FileMonitorEvent)) glib.SignalHandle {
+	return v.Connect("changed", func(_, file, otherFile *glib.Object, event int) {
+		f(v, signalFile(file), signalFile(otherFile), FileMonitorEvent(event))
+	})
+}
+
+// signalFile() wraps a GFile passed as a signal argument, adding a
+// reference for Go.  nil is returned for a NULL GFile.
+func signalFile(obj *glib.Object) *File {
+	if obj == nil || obj.Ptr() == nil {
+		return nil
+	}
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapFile(obj)
+}
+
+/*
+ * FileInfo
+ */
+
+// FileInfo is a representation of GIO's GFileInfo.
+type FileInfo struct {
+	*glib.Object
+}
+
+func wrapFileInfo(obj *glib.Object) *FileInfo {
+	return &FileInfo{obj}
+}
+
+func fileInfoFromNative(c *C.GFileInfo) *FileInfo {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	info := wrapFileInfo(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return info
+}
+
+// Native() returns a pointer to the underlying GFileInfo.
+func (v *FileInfo) Native() *C.GFileInfo {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GFileInfo)(v.Ptr())
+}
+
+// GetName() is a wrapper around g_file_info_get_name().
+func (v *FileInfo) GetName() string {
+	c := C.g_file_info_get_name(v.Native())
+	return C.GoString(c)
+}
+
+// GetDisplayName() is a wrapper around g_file_info_get_display_name().
+func (v *FileInfo) GetDisplayName() string {
+	c := C.g_file_info_get_display_name(v.Native())
+	return C.GoString(c)
+}
+
+// GetContentType() is a wrapper around g_file_info_get_content_type().
+func (v *FileInfo) GetContentType() string {
+	c := C.g_file_info_get_content_type(v.Native())
+	return C.GoString(c)
+}
+
+// GetFileType() is a wrapper around g_file_info_get_file_type().
+func (v *FileInfo) GetFileType() FileType {
+	c := C.g_file_info_get_file_type(v.Native())
+	return FileType(c)
+}
+
+// GetSize() is a wrapper around g_file_info_get_size().
+func (v *FileInfo) GetSize() int64 {
+	c := C.g_file_info_get_size(v.Native())
+	return int64(c)
+}
+
+// GetIsHidden() is a wrapper around g_file_info_get_is_hidden().
+func (v *FileInfo) GetIsHidden() bool {
+	c := C.g_file_info_get_is_hidden(v.Native())
+	return gobool(c)
+}
+
+// GetIsSymlink() is a wrapper around g_file_info_get_is_symlink().
+func (v *FileInfo) GetIsSymlink() bool {
+	c := C.g_file_info_get_is_symlink(v.Native())
+	return gobool(c)
+}
+
+// GetModificationTime() returns the file's modification time from the
+// "time::modified" and "time::modified-usec" attributes.  The bool is
+// false if "time::modified" was not queried.
+func (v *FileInfo) GetModificationTime() (time.Time, bool) {
+	if !v.HasAttribute("time::modified") {
+		return time.Time{}, false
+	}
+	sec := v.GetAttributeUint64("time::modified")
+	var usec uint32
+	if v.HasAttribute("time::modified-usec") {
+		usec = v.GetAttributeUint32("time::modified-usec")
+	}
+	return time.Unix(int64(sec), int64(usec)*1000), true
+}
+
+// HasAttribute() is a wrapper around g_file_info_has_attribute().
+func (v *FileInfo) HasAttribute(attribute string) bool {
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_file_info_has_attribute(v.Native(), (*C.char)(cstr))
+	return gobool(c)
+}
+
+// ListAttributes() is a wrapper around g_file_info_list_attributes().
+// An empty namespace lists all attributes.
+func (v *FileInfo) ListAttributes(namespace string) []string {
+	cstr := cStringOrNil(namespace)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_file_info_list_attributes(v.Native(), (*C.char)(cstr))
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// GetAttributeString() is a wrapper around
+// g_file_info_get_attribute_string().
+func (v *FileInfo) GetAttributeString(attribute string) string {
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_file_info_get_attribute_string(v.Native(), (*C.char)(cstr))
+	return C.GoString(c)
+}
+
+// GetAttributeUint32() is a wrapper around
+// g_file_info_get_attribute_uint32().
+func (v *FileInfo) GetAttributeUint32(attribute string) uint32 {
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_file_info_get_attribute_uint32(v.Native(), (*C.char)(cstr))
+	return uint32(c)
+}
+
+// GetAttributeUint64() is a wrapper around
+// g_file_info_get_attribute_uint64().
+func (v *FileInfo) GetAttributeUint64(attribute string) uint64 {
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_file_info_get_attribute_uint64(v.Native(), (*C.char)(cstr))
+	return uint64(c)
+}
+
+// GetAttributeBoolean() is a wrapper around
+// g_file_info_get_attribute_boolean().
+func (v *FileInfo) GetAttributeBoolean(attribute string) bool {
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_file_info_get_attribute_boolean(v.Native(), (*C.char)(cstr))
+	return gobool(c)
+}
+
+/*
+ * FileEnumerator
+ */
+
+// FileEnumerator is a representation of GIO's GFileEnumerator.
+type FileEnumerator struct {
+	*glib.Object
+}
+
+func wrapFileEnumerator(obj *glib.Object) *FileEnumerator {
+	return &FileEnumerator{obj}
+}
+
+func fileEnumeratorFromNative(c *C.GFileEnumerator) *FileEnumerator {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	e := wrapFileEnumerator(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return e
+}
+
+// Native() returns a pointer to the underlying GFileEnumerator.
+func (v *FileEnumerator) Native() *C.GFileEnumerator {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GFileEnumerator)(v.Ptr())
+}
+
+// NextFile() is a wrapper around g_file_enumerator_next_file().  io.EOF
+// is returned once all children have been enumerated.
+func (v *FileEnumerator) NextFile() (*FileInfo, error) {
+	var err *C.GError = nil
+	c := C.g_file_enumerator_next_file(v.Native(), nil, &err)
+	if c == nil {
+		if err != nil {
+			return nil, goError(err)
+		}
+		return nil, io.EOF
+	}
+	return fileInfoFromNative(c), nil
+}
+
+// GetChild() is a wrapper around g_file_enumerator_get_child().
+func (v *FileEnumerator) GetChild(info *FileInfo) (*File, error) {
+	return fileFromNative(C.g_file_enumerator_get_child(v.Native(),
+		info.Native()))
+}
+
+// Close() is a wrapper around g_file_enumerator_close().
+func (v *FileEnumerator) Close() error {
+	var err *C.GError = nil
+	c := C.g_file_enumerator_close(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+/*
+ * Icon
+ */
+
+// Icon is a representation of GIO's GIcon GInterface.
+type Icon struct {
+	*glib.Object
+}
+
+// IIcon is an interface type implemented by all structs embedding an
+// Icon.  It is meant to be used as an argument type for wrapper functions
+// that wrap around a C function taking a GIcon.
+type IIcon interface {
+	glib.IObject
+	toIcon() *C.GIcon
+}
+
+// Native() returns a pointer to the underlying GIcon.
+func (v *Icon) Native() *C.GIcon {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GIcon)(v.Ptr())
+}
+
+func (v *Icon) toIcon() *C.GIcon {
+	return v.Native()
+}
+
+// icon() returns the GIcon underlying icon, or nil if icon is nil.
+func icon(icon IIcon) *C.GIcon {
+	if icon == nil {
+		return nil
+	}
+	return icon.toIcon()
+}
+
+// iconFromNative() wraps a GIcon, adding a reference if the caller does
+// not own one.
+func iconFromNative(c *C.GIcon, transfer glib.Transfer) *Icon {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	if transfer == glib.TRANSFER_NONE {
+		obj.Ref()
+	}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &Icon{obj}
+}
+
+// IconNewForString() is a wrapper around g_icon_new_for_string().  str
+// may be a string returned by ToString(), a themed icon name, or a path
+// or URI.
+func IconNewForString(str string) (*Icon, error) {
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_icon_new_for_string((*C.gchar)(cstr), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return iconFromNative(c, glib.TRANSFER_FULL), nil
+}
+
+// IconDeserialize() is a wrapper around g_icon_deserialize().
+func IconDeserialize(value *glib.Variant) (*Icon, error) {
+	c := C.g_icon_deserialize(variantPtr(value))
+	if c == nil {
+		return nil, errors.New("unable to deserialize icon")
+	}
+	return iconFromNative(c, glib.TRANSFER_FULL), nil
+}
+
+// ToString() is a wrapper around g_icon_to_string().  An error is
+// returned if the icon cannot be represented as a string.
+func (v *Icon) ToString() (string, error) {
+	c := C.g_icon_to_string(v.Native())
+	if c == nil {
+		return "", errors.New("icon has no string representation")
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), nil
+}
+
+// Serialize() is a wrapper around g_icon_serialize().
+func (v *Icon) Serialize() (*glib.Variant, error) {
+	c := C.g_icon_serialize(v.Native())
+	if c == nil {
+		return nil, errors.New("icon cannot be serialized")
+	}
+	return goVariant(c), nil
+}
+
+// Equal() is a wrapper around g_icon_equal().
+func (v *Icon) Equal(other IIcon) bool {
+	c := C.g_icon_equal(v.Native(), icon(other))
+	return gobool(c)
+}
+
+/*
+ * ThemedIcon
+ */
+
+// ThemedIcon is a representation of GIO's GThemedIcon.
+type ThemedIcon struct {
+	*glib.Object
+
+	// Interfaces
+	Icon
+}
+
+func wrapThemedIcon(obj *glib.Object) *ThemedIcon {
+	return &ThemedIcon{obj, Icon{obj}}
+}
+
+// Native() returns a pointer to the underlying GThemedIcon.
+func (v *ThemedIcon) Native() *C.GThemedIcon {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GThemedIcon)(v.Ptr())
+}
+
+func themedIconFromNative(c *C.GIcon) (*ThemedIcon, error) {
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	i := wrapThemedIcon(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return i, nil
+}
+
+// ThemedIconNew() is a wrapper around g_themed_icon_new().
+func ThemedIconNew(iconName string) (*ThemedIcon, error) {
+	cstr := C.CString(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	return themedIconFromNative(C.g_themed_icon_new((*C.char)(cstr)))
+}
+
+// ThemedIconNewWithDefaultFallbacks() is a wrapper around
+// g_themed_icon_new_with_default_fallbacks().
+func ThemedIconNewWithDefaultFallbacks(iconName string) (*ThemedIcon, error) {
+	cstr := C.CString(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	return themedIconFromNative(
+		C.g_themed_icon_new_with_default_fallbacks((*C.char)(cstr)))
+}
+
+// ThemedIconNewFromNames() is a wrapper around
+// g_themed_icon_new_from_names().
+func ThemedIconNewFromNames(iconNames []string) (*ThemedIcon, error) {
+	cstrs := make([]*C.char, len(iconNames)+1)
+	for i, name := range iconNames {
+		cstrs[i] = C.CString(name)
+		defer C.free(unsafe.Pointer(cstrs[i]))
+	}
+	return themedIconFromNative(C.g_themed_icon_new_from_names(&cstrs[0],
+		C.int(len(iconNames))))
+}
+
+// PrependName() is a wrapper around g_themed_icon_prepend_name().
+func (v *ThemedIcon) PrependName(iconName string) {
+	cstr := C.CString(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_themed_icon_prepend_name(v.Native(), (*C.char)(cstr))
+}
+
+// AppendName() is a wrapper around g_themed_icon_append_name().
+func (v *ThemedIcon) AppendName(iconName string) {
+	cstr := C.CString(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_themed_icon_append_name(v.Native(), (*C.char)(cstr))
+}
+
+// GetNames() is a wrapper around g_themed_icon_get_names().
+func (v *ThemedIcon) GetNames() []string {
+	c := C.g_themed_icon_get_names(v.Native())
+	return goStrv((**C.gchar)(unsafe.Pointer(c)))
+}
+
+/*
+ * FileIcon
+ */
+
+// FileIcon is a representation of GIO's GFileIcon.
+type FileIcon struct {
+	*glib.Object
+
+	// Interfaces
+	Icon
+}
+
+// Native() returns a pointer to the underlying GFileIcon.
+func (v *FileIcon) Native() *C.GFileIcon {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GFileIcon)(v.Ptr())
+}
+
+// FileIconNew() is a wrapper around g_file_icon_new().
+func FileIconNew(file *File) (*FileIcon, error) {
+	c := C.g_file_icon_new(file.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	i := &FileIcon{obj, Icon{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return i, nil
+}
+
+// GetFile() is a wrapper around g_file_icon_get_file().
+func (v *FileIcon) GetFile() *File {
+	c := C.g_file_icon_get_file(v.Native())
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapFile(obj)
+}
+
+/*
+ * BytesIcon
+ */
+
+// BytesIcon is a representation of GIO's GBytesIcon.
+type BytesIcon struct {
+	*glib.Object
+
+	// Interfaces
+	Icon
+}
+
+// Native() returns a pointer to the underlying GBytesIcon.
+func (v *BytesIcon) Native() *C.GBytesIcon {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GBytesIcon)(v.Ptr())
+}
+
+// BytesIconNew() is a wrapper around g_bytes_icon_new().  data holds an
+// encoded image, such as a PNG or SVG file, and is copied.
+func BytesIconNew(data []byte) (*BytesIcon, error) {
+	var p unsafe.Pointer
+	if len(data) > 0 {
+		p = unsafe.Pointer(&data[0])
+	}
+	bytes := C.g_bytes_new(C.gconstpointer(p), C.gsize(len(data)))
+	defer C.g_bytes_unref(bytes)
+	c := C.g_bytes_icon_new(bytes)
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	i := &BytesIcon{obj, Icon{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return i, nil
+}
+
+// GetBytes() is a wrapper around g_bytes_icon_get_bytes().
+func (v *BytesIcon) GetBytes() []byte {
+	c := C.g_bytes_icon_get_bytes(v.Native())
+	return goBytes(C.g_bytes_ref(c))
+}
+
+/*
+ * Emblem
+ */
+
+type EmblemOrigin int
+
+const (
+	EMBLEM_ORIGIN_UNKNOWN      EmblemOrigin = C.G_EMBLEM_ORIGIN_UNKNOWN
+	EMBLEM_ORIGIN_DEVICE                    = C.G_EMBLEM_ORIGIN_DEVICE
+	EMBLEM_ORIGIN_LIVEMETADATA              = C.G_EMBLEM_ORIGIN_LIVEMETADATA
+	EMBLEM_ORIGIN_TAG                       = C.G_EMBLEM_ORIGIN_TAG
+)
+
+// Emblem is a representation of GIO's GEmblem.
+type Emblem struct {
+	*glib.Object
+
+	// Interfaces
+	Icon
+}
+
+func wrapEmblem(obj *glib.Object) *Emblem {
+	return &Emblem{obj, Icon{obj}}
+}
+
+// Native() returns a pointer to the underlying GEmblem.
+func (v *Emblem) Native() *C.GEmblem {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GEmblem)(v.Ptr())
+}
+
+// EmblemNew() is a wrapper around g_emblem_new().
+func EmblemNew(emblemIcon IIcon) (*Emblem, error) {
+	return EmblemNewWithOrigin(emblemIcon, EMBLEM_ORIGIN_UNKNOWN)
+}
+
+// EmblemNewWithOrigin() is a wrapper around g_emblem_new_with_origin().
+func EmblemNewWithOrigin(emblemIcon IIcon, origin EmblemOrigin) (*Emblem, error) {
+	c := C.g_emblem_new_with_origin(icon(emblemIcon),
+		C.GEmblemOrigin(origin))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	e := wrapEmblem(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return e, nil
+}
+
+// GetIcon() is a wrapper around g_emblem_get_icon().
+func (v *Emblem) GetIcon() *Icon {
+	c := C.g_emblem_get_icon(v.Native())
+	return iconFromNative(c, glib.TRANSFER_NONE)
+}
+
+// GetOrigin() is a wrapper around g_emblem_get_origin().
+func (v *Emblem) GetOrigin() EmblemOrigin {
+	c := C.g_emblem_get_origin(v.Native())
+	return EmblemOrigin(c)
+}
+
+/*
+ * EmblemedIcon
+ */
+
+// EmblemedIcon is a representation of GIO's GEmblemedIcon.
+type EmblemedIcon struct {
+	*glib.Object
+
+	// Interfaces
+	Icon
+}
+
+// Native() returns a pointer to the underlying GEmblemedIcon.
+func (v *EmblemedIcon) Native() *C.GEmblemedIcon {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GEmblemedIcon)(v.Ptr())
+}
+
+// EmblemedIconNew() is a wrapper around g_emblemed_icon_new().  emblem
+// may be nil.
+func EmblemedIconNew(baseIcon IIcon, emblem *Emblem) (*EmblemedIcon, error) {
+	c := C.g_emblemed_icon_new(icon(baseIcon), emblem.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	i := &EmblemedIcon{obj, Icon{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return i, nil
+}
+
+// GetIcon() is a wrapper around g_emblemed_icon_get_icon().
+func (v *EmblemedIcon) GetIcon() *Icon {
+	c := C.g_emblemed_icon_get_icon(v.Native())
+	return iconFromNative(c, glib.TRANSFER_NONE)
+}
+
+// GetEmblems() is a wrapper around g_emblemed_icon_get_emblems().
+func (v *EmblemedIcon) GetEmblems() []*Emblem {
+	c := C.g_emblemed_icon_get_emblems(v.Native())
+	s := glib.ListToSlice(unsafe.Pointer(c), glib.TRANSFER_NONE)
+	emblems := make([]*Emblem, len(s))
+	for i, p := range s {
+		obj := glib.ObjectNew(p)
+		obj.Ref()
+		runtime.SetFinalizer(obj, (*glib.Object).Unref)
+		emblems[i] = wrapEmblem(obj)
+	}
+	return emblems
+}
+
+// AddEmblem() is a wrapper around g_emblemed_icon_add_emblem().
+func (v *EmblemedIcon) AddEmblem(emblem *Emblem) {
+	C.g_emblemed_icon_add_emblem(v.Native(), emblem.Native())
+}
+
+// ClearEmblems() is a wrapper around g_emblemed_icon_clear_emblems().
+func (v *EmblemedIcon) ClearEmblems() {
+	C.g_emblemed_icon_clear_emblems(v.Native())
+}
+
+/*
+ * ListModel
+ */
+
+// ListModel is a representation of GIO's GListModel GInterface.
+type ListModel struct {
+	*glib.Object
+}
+
+// IListModel is an interface type implemented by all structs embedding a
+// ListModel.  It is meant to be used as an argument type for wrapper
+// functions that wrap around a C function taking a GListModel.
+type IListModel interface {
+	glib.IObject
+	toListModel() *C.GListModel
+}
+
+// Native() returns a pointer to the underlying GListModel.
+func (v *ListModel) Native() *C.GListModel {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GListModel)(v.Ptr())
+}
+
+func (v *ListModel) toListModel() *C.GListModel {
+	return v.Native()
+}
+
+// GetItemType() is a wrapper around g_list_model_get_item_type().
+func (v *ListModel) GetItemType() glib.Type {
+	c := C.g_list_model_get_item_type(v.Native())
+	return glib.Type(c)
+}
+
+// GetNItems() is a wrapper around g_list_model_get_n_items().
+func (v *ListModel) GetNItems() uint {
+	c := C.g_list_model_get_n_items(v.Native())
+	return uint(c)
+}
+
+// GetItem() is a wrapper around g_list_model_get_object().  A non-nil
+// error is returned if position is out of range.
+func (v *ListModel) GetItem(position uint) (*glib.Object, error) {
+	c := C.g_list_model_get_object(v.Native(), C.guint(position))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return obj, nil
+}
+
+// ItemsChanged() is a wrapper around g_list_model_items_changed().  It
+// must be called by the owner of a model created with ListModelNew()
+// after each change to its items.
+func (v *ListModel) ItemsChanged(position, removed, added uint) {
+	C.g_list_model_items_changed(v.Native(), C.guint(position),
+		C.guint(removed), C.guint(added))
+}
+
+// OnItemsChanged() connects f to the model's "items-changed" signal.
+func (v *ListModel) OnItemsChanged(f func(position, removed, added uint)) glib.SignalHandle {
+	return v.Connect("items-changed", func(_ *glib.Object, position, removed, added uint) {
+		f(position, removed, added)
+	})
+}
+
+// ListModelSource is implemented by Go types which provide the items of
+// a ListModel created with ListModelNew().  Its methods are called from
+// the main loop, and correspond to those of GListModelInterface.  GetItem
+// returns nil if position is out of range.
+type ListModelSource interface {
+	GetItemType() glib.Type
+	GetNItems() uint
+	GetItem(position uint) glib.IObject
+}
+
+// listModelSources holds the sources of models created with
+// ListModelNew(), keyed by the user data stored in each model.
+var listModelSources = struct {
+	sync.RWMutex
+	m map[C.gpointer]ListModelSource
+}{
+	m: make(map[C.gpointer]ListModelSource),
+}
+
+func listModelSource(data C.gpointer) ListModelSource {
+	listModelSources.RLock()
+	defer listModelSources.RUnlock()
+	return listModelSources.m[data]
+}
+
+//export goListModelGetItemType
+func goListModelGetItemType(data C.gpointer) C.GType {
+	return C.GType(listModelSource(data).GetItemType())
+}
+
+//export goListModelGetNItems
+func goListModelGetNItems(data C.gpointer) C.guint {
+	return C.guint(listModelSource(data).GetNItems())
+}
+
+//export goListModelGetItem
+func goListModelGetItem(data C.gpointer, position C.guint) C.gpointer {
+	item := listModelSource(data).GetItem(uint(position))
+	if item == nil {
+		return nil
+	}
+	obj := item.ToObject()
+	obj.Ref()
+	return C.gpointer(obj.Ptr())
+}
+
+//export goListModelFree
+func goListModelFree(data C.gpointer) {
+	listModelSources.Lock()
+	delete(listModelSources.m, data)
+	listModelSources.Unlock()
+	C.free(unsafe.Pointer(data))
+}
+
+// ListModelNew() creates a ListModel whose items are provided by source,
+// allowing a Go type to back a model with its own storage.  The model
+// does not track changes to source, so ItemsChanged() must be called
+// whenever its items are changed.
+func ListModelNew(source ListModelSource) (*ListModel, error) {
+	data := C.gpointer(C.malloc(1))
+	listModelSources.Lock()
+	listModelSources.m[data] = source
+	listModelSources.Unlock()
+	c := C._go_list_model_new(data)
+	if c == nil {
+		goListModelFree(data)
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := &ListModel{obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m, nil
+}
+
+/*
+ * ListStore
+ */
+
+// ListStore is a representation of GIO's GListStore.
+type ListStore struct {
+	*glib.Object
+
+	// Interfaces
+	ListModel
+}
+
+// Native() returns a pointer to the underlying GListStore.
+func (v *ListStore) Native() *C.GListStore {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GListStore)(v.Ptr())
+}
+
+// ListStoreNew() is a wrapper around g_list_store_new().  Every item
+// added to the store must be an instance of itemType.
+func ListStoreNew(itemType glib.Type) (*ListStore, error) {
+	c := C.g_list_store_new(C.GType(itemType))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	s := &ListStore{obj, ListModel{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return s, nil
+}
+
+// Append() is a wrapper around g_list_store_append().
+func (v *ListStore) Append(item glib.IObject) {
+	C.g_list_store_append(v.Native(), C.gpointer(item.ToObject().Ptr()))
+}
+
+// Insert() is a wrapper around g_list_store_insert().
+func (v *ListStore) Insert(position uint, item glib.IObject) {
+	C.g_list_store_insert(v.Native(), C.guint(position),
+		C.gpointer(item.ToObject().Ptr()))
+}
+
+// InsertSorted() is a wrapper around g_list_store_insert_sorted().  The
+// store must already be sorted by compare, which returns a negative
+// number, zero or a positive number as a sorts before, equal to or after
+// b.  The position of the inserted item is returned.
+func (v *ListStore) InsertSorted(item glib.IObject, compare func(a, b *glib.Object) int) uint {
+	data := registerCompareFunc(compare)
+	defer unregisterCompareFunc(data)
+	c := C._g_list_store_insert_sorted(v.Native(),
+		C.gpointer(item.ToObject().Ptr()), data)
+	return uint(c)
+}
+
+// Remove() is a wrapper around g_list_store_remove().
+func (v *ListStore) Remove(position uint) {
+	C.g_list_store_remove(v.Native(), C.guint(position))
+}
+
+// RemoveAll() is a wrapper around g_list_store_remove_all().
+func (v *ListStore) RemoveAll() {
+	C.g_list_store_remove_all(v.Native())
+}
+
+// Splice() is a wrapper around g_list_store_splice().  nRemovals items
+// are removed at position and replaced by additions, emitting a single
+// "items-changed" signal.
+func (v *ListStore) Splice(position, nRemovals uint, additions []glib.IObject) {
+	items := make([]C.gpointer, len(additions)+1)
+	for i, item := range additions {
+		items[i] = C.gpointer(item.ToObject().Ptr())
+	}
+	C.g_list_store_splice(v.Native(), C.guint(position),
+		C.guint(nRemovals), &items[0], C.guint(len(additions)))
+}
+
+// Sort() is a wrapper around g_list_store_sort().  compare is as for
+// InsertSorted().
+func (v *ListStore) Sort(compare func(a, b *glib.Object) int) {
+	data := registerCompareFunc(compare)
+	defer unregisterCompareFunc(data)
+	C._g_list_store_sort(v.Native(), data)
+}
+
+// compareFuncs holds the comparison functions of in-progress sorts, keyed
+// by the user data passed to _g_compare_data_func().
+var compareFuncs = struct {
+	sync.RWMutex
+	m map[C.gpointer]func(a, b *glib.Object) int
+}{
+	m: make(map[C.gpointer]func(a, b *glib.Object) int),
+}
+
+func registerCompareFunc(compare func(a, b *glib.Object) int) C.gpointer {
+	data := C.gpointer(C.malloc(1))
+	compareFuncs.Lock()
+	compareFuncs.m[data] = compare
+	compareFuncs.Unlock()
+	return data
+}
+
+func unregisterCompareFunc(data C.gpointer) {
+	compareFuncs.Lock()
+	delete(compareFuncs.m, data)
+	compareFuncs.Unlock()
+	C.free(unsafe.Pointer(data))
+}
+
+//export goCompareDataFunc
+func goCompareDataFunc(a, b, data C.gpointer) C.gint {
+	compareFuncs.RLock()
+	compare := compareFuncs.m[data]
+	compareFuncs.RUnlock()
+	return C.gint(compare(glib.ObjectNew(unsafe.Pointer(a)),
+		glib.ObjectNew(unsafe.Pointer(b))))
+}
+
+/*
+ * MenuModel
+ */
+
+// MenuModel is a representation of GIO's GMenuModel.
+type MenuModel struct {
+	*glib.Object
+}
+
+// IMenuModel is an interface type implemented by all structs embedding a
+// MenuModel.  It is meant to be used as an argument type for wrapper
+// functions that wrap around a C function taking a GMenuModel.
+type IMenuModel interface {
+	glib.IObject
+	toMenuModel() *C.GMenuModel
+}
+
+func wrapMenuModel(obj *glib.Object) *MenuModel {
+	return &MenuModel{obj}
+}
+
+// Native() returns a pointer to the underlying GMenuModel.
+func (v *MenuModel) Native() *C.GMenuModel {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GMenuModel)(v.Ptr())
+}
+
+func (v *MenuModel) toMenuModel() *C.GMenuModel {
+	return v.Native()
+}
+
+// menuModel() returns the GMenuModel underlying model, or nil if model is
+// nil.
+func menuModel(model IMenuModel) *C.GMenuModel {
+	if model == nil {
+		return nil
+	}
+	return model.toMenuModel()
+}
+
+// IsMutable() is a wrapper around g_menu_model_is_mutable().
+func (v *MenuModel) IsMutable() bool {
+	c := C.g_menu_model_is_mutable(v.Native())
+	return gobool(c)
+}
+
+// GetNItems() is a wrapper around g_menu_model_get_n_items().
+func (v *MenuModel) GetNItems() int {
+	c := C.g_menu_model_get_n_items(v.Native())
+	return int(c)
+}
+
+// GetItemAttributeValue() is a wrapper around
+// g_menu_model_get_item_attribute_value().  expectedType is a variant type
+// string, or empty to accept a value of any type.  nil is returned if the
+// attribute does not exist or does not have the expected type.
+func (v *MenuModel) GetItemAttributeValue(itemIndex int, attribute, expectedType string) (*glib.Variant, error) {
+	t, err := variantType(expectedType)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		defer C.g_variant_type_free(t)
+	}
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_menu_model_get_item_attribute_value(v.Native(),
+		C.gint(itemIndex), (*C.gchar)(cstr), t)
+	return goVariant(c), nil
+}
+
+// GetItemLink() is a wrapper around g_menu_model_get_item_link().
+func (v *MenuModel) GetItemLink(itemIndex int, link string) (*MenuModel, error) {
+	cstr := C.CString(link)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_menu_model_get_item_link(v.Native(), C.gint(itemIndex),
+		(*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapMenuModel(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m, nil
+}
+
+// OnItemsChanged() connects f to the model's "items-changed" signal.
+func (v *MenuModel) OnItemsChanged(f func(position, removed, added int)) glib.SignalHandle {
+	return v.Connect("items-changed", func(_ *glib.Object, position, removed, added int) {
+		f(position, removed, added)
+	})
+}
+
+/*
+ * Menu
+ */
+
+// Menu is a representation of GIO's GMenu.
+type Menu struct {
+	MenuModel
+}
+
+func wrapMenu(obj *glib.Object) *Menu {
+	return &Menu{MenuModel{obj}}
+}
+
+// Native() returns a pointer to the underlying GMenu.
+func (v *Menu) Native() *C.GMenu {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GMenu)(v.Ptr())
+}
+
+// MenuNew() is a wrapper around g_menu_new().
+func MenuNew() (*Menu, error) {
+	c := C.g_menu_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapMenu(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m, nil
+}
+
+// Freeze() is a wrapper around g_menu_freeze().
+func (v *Menu) Freeze() {
+	C.g_menu_freeze(v.Native())
+}
+
+// Insert() is a wrapper around g_menu_insert().  An empty label or
+// detailedAction leaves the corresponding attribute unset.
+func (v *Menu) Insert(position int, label, detailedAction string) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	caction := cStringOrNil(detailedAction)
+	defer C.free(unsafe.Pointer(caction))
+	C.g_menu_insert(v.Native(), C.gint(position), clabel, caction)
+}
+
+// Prepend() is a wrapper around g_menu_prepend().
+func (v *Menu) Prepend(label, detailedAction string) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	caction := cStringOrNil(detailedAction)
+	defer C.free(unsafe.Pointer(caction))
+	C.g_menu_prepend(v.Native(), clabel, caction)
+}
+
+// Append() is a wrapper around g_menu_append().
+func (v *Menu) Append(label, detailedAction string) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	caction := cStringOrNil(detailedAction)
+	defer C.free(unsafe.Pointer(caction))
+	C.g_menu_append(v.Native(), clabel, caction)
+}
+
+// InsertItem() is a wrapper around g_menu_insert_item().
+func (v *Menu) InsertItem(position int, item *MenuItem) {
+	C.g_menu_insert_item(v.Native(), C.gint(position), item.Native())
+}
+
+// PrependItem() is a wrapper around g_menu_prepend_item().
+func (v *Menu) PrependItem(item *MenuItem) {
+	C.g_menu_prepend_item(v.Native(), item.Native())
+}
+
+// AppendItem() is a wrapper around g_menu_append_item().
+func (v *Menu) AppendItem(item *MenuItem) {
+	C.g_menu_append_item(v.Native(), item.Native())
+}
+
+// InsertSection() is a wrapper around g_menu_insert_section().  An empty
+// label creates a section without a heading.
+func (v *Menu) InsertSection(position int, label string, section IMenuModel) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_insert_section(v.Native(), C.gint(position), clabel,
+		menuModel(section))
+}
+
+// PrependSection() is a wrapper around g_menu_prepend_section().
+func (v *Menu) PrependSection(label string, section IMenuModel) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_prepend_section(v.Native(), clabel, menuModel(section))
+}
+
+// AppendSection() is a wrapper around g_menu_append_section().
+func (v *Menu) AppendSection(label string, section IMenuModel) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_append_section(v.Native(), clabel, menuModel(section))
+}
+
+// InsertSubmenu() is a wrapper around g_menu_insert_submenu().
+func (v *Menu) InsertSubmenu(position int, label string, submenu IMenuModel) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_insert_submenu(v.Native(), C.gint(position), clabel,
+		menuModel(submenu))
+}
+
+// PrependSubmenu() is a wrapper around g_menu_prepend_submenu().
+func (v *Menu) PrependSubmenu(label string, submenu IMenuModel) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_prepend_submenu(v.Native(), clabel, menuModel(submenu))
+}
+
+// AppendSubmenu() is a wrapper around g_menu_append_submenu().
+func (v *Menu) AppendSubmenu(label string, submenu IMenuModel) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_append_submenu(v.Native(), clabel, menuModel(submenu))
+}
+
+// Remove() is a wrapper around g_menu_remove().
+func (v *Menu) Remove(position int) {
+	C.g_menu_remove(v.Native(), C.gint(position))
+}
+
+/*
+ * MenuItem
+ */
+
+// MenuItem is a representation of GIO's GMenuItem.
+type MenuItem struct {
+	*glib.Object
+}
+
+func wrapMenuItem(obj *glib.Object) *MenuItem {
+	return &MenuItem{obj}
+}
+
+// Native() returns a pointer to the underlying GMenuItem.
+func (v *MenuItem) Native() *C.GMenuItem {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GMenuItem)(v.Ptr())
+}
+
+func menuItemFromNative(c *C.GMenuItem) (*MenuItem, error) {
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapMenuItem(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m, nil
+}
+
+// MenuItemNew() is a wrapper around g_menu_item_new().  An empty label or
+// detailedAction leaves the corresponding attribute unset.
+func MenuItemNew(label, detailedAction string) (*MenuItem, error) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	caction := cStringOrNil(detailedAction)
+	defer C.free(unsafe.Pointer(caction))
+	return menuItemFromNative(C.g_menu_item_new(clabel, caction))
+}
+
+// MenuItemNewSection() is a wrapper around g_menu_item_new_section().
+func MenuItemNewSection(label string, section IMenuModel) (*MenuItem, error) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	return menuItemFromNative(C.g_menu_item_new_section(clabel,
+		menuModel(section)))
+}
+
+// MenuItemNewSubmenu() is a wrapper around g_menu_item_new_submenu().
+func MenuItemNewSubmenu(label string, submenu IMenuModel) (*MenuItem, error) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	return menuItemFromNative(C.g_menu_item_new_submenu(clabel,
+		menuModel(submenu)))
+}
+
+// MenuItemNewFromModel() is a wrapper around g_menu_item_new_from_model().
+func MenuItemNewFromModel(model IMenuModel, itemIndex int) (*MenuItem, error) {
+	return menuItemFromNative(C.g_menu_item_new_from_model(
+		menuModel(model), C.gint(itemIndex)))
+}
+
+// SetLabel() is a wrapper around g_menu_item_set_label().
+func (v *MenuItem) SetLabel(label string) {
+	clabel := cStringOrNil(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.g_menu_item_set_label(v.Native(), clabel)
+}
+
+// SetDetailedAction() is a wrapper around
+// g_menu_item_set_detailed_action().
+func (v *MenuItem) SetDetailedAction(detailedAction string) {
+	cstr := C.CString(detailedAction)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_menu_item_set_detailed_action(v.Native(), (*C.gchar)(cstr))
+}
+
+// SetActionAndTarget() is a wrapper around
+// g_menu_item_set_action_and_target_value().  target may be nil for
+// actions which take no parameter.
+func (v *MenuItem) SetActionAndTarget(action string, target *glib.Variant) {
+	caction := cStringOrNil(action)
+	defer C.free(unsafe.Pointer(caction))
+	C.g_menu_item_set_action_and_target_value(v.Native(), caction,
+		variantPtr(target))
+}
+
+// SetSection() is a wrapper around g_menu_item_set_section().
+func (v *MenuItem) SetSection(section IMenuModel) {
+	C.g_menu_item_set_section(v.Native(), menuModel(section))
+}
+
+// SetSubmenu() is a wrapper around g_menu_item_set_submenu().
+func (v *MenuItem) SetSubmenu(submenu IMenuModel) {
+	C.g_menu_item_set_submenu(v.Native(), menuModel(submenu))
+}
+
+// SetAttributeValue() is a wrapper around
+// g_menu_item_set_attribute_value().  A nil value unsets the attribute.
+func (v *MenuItem) SetAttributeValue(attribute string, value *glib.Variant) {
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_menu_item_set_attribute_value(v.Native(), (*C.gchar)(cstr),
+		variantPtr(value))
+}
+
+// GetAttributeValue() is a wrapper around
+// g_menu_item_get_attribute_value().  expectedType is a variant type
+// string, or empty to accept a value of any type.  nil is returned if the
+// attribute does not exist or does not have the expected type.
+func (v *MenuItem) GetAttributeValue(attribute, expectedType string) (*glib.Variant, error) {
+	t, err := variantType(expectedType)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		defer C.g_variant_type_free(t)
+	}
+	cstr := C.CString(attribute)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_menu_item_get_attribute_value(v.Native(), (*C.gchar)(cstr), t)
+	return goVariant(c), nil
+}
+
+// SetLink() is a wrapper around g_menu_item_set_link().  A nil model
+// removes the link.
+func (v *MenuItem) SetLink(link string, model IMenuModel) {
+	cstr := C.CString(link)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_menu_item_set_link(v.Native(), (*C.gchar)(cstr), menuModel(model))
+}
+
+// GetLink() is a wrapper around g_menu_item_get_link().
+func (v *MenuItem) GetLink(link string) (*MenuModel, error) {
+	cstr := C.CString(link)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_menu_item_get_link(v.Native(), (*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapMenuModel(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m, nil
+}
+
+/*
+ * InputStream
+ */
+
+// InputStream is a representation of GIO's GInputStream.  It implements
+// io.Reader and io.Closer using blocking reads.
+type InputStream struct {
+	*glib.Object
+}
+
+func wrapInputStream(obj *glib.Object) *InputStream {
+	return &InputStream{obj}
+}
+
+// Native() returns a pointer to the underlying GInputStream.
+func (v *InputStream) Native() *C.GInputStream {
+	if v == nil || v.Ptr() == nil {
+		return nil
+	}
+	return (*C.GInputStream)(v.Ptr())
+}
+
+// Read() is a wrapper around g_input_stream_read().  io.EOF is returned
+// once the end of the stream is reached.
+func (v *InputStream) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	var err *C.GError = nil
+	c := C.g_input_stream_read(v.Native(), unsafe.Pointer(&p[0]),
+		C.gsize(len(p)), nil, &err)
+	if c < 0 {
+		return 0, goError(err)
+	}
+	if c == 0 {
+		return 0, io.EOF
+	}
+	return int(c), nil
+}
+
+// ReadAsync() is a wrapper around g_input_stream_read_async().  f is
+// called from the main loop with up to count bytes once data is available
+// or ctx is done, and with io.EOF once the end of the stream is reached.
+func (v *InputStream) ReadAsync(ctx context.Context, count int, f func(data []byte, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	buf := C.malloc(C.size_t(count))
+	C.g_input_stream_read_async(v.Native(), buf, C.gsize(count),
+		C.G_PRIORITY_DEFAULT, cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			defer C.free(buf)
+			var err *C.GError = nil
+			c := C.g_input_stream_read_finish(v.Native(), result, &err)
+			if c < 0 {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			if c == 0 && count > 0 {
+				f(nil, io.EOF)
+				return
+			}
+			f(C.GoBytes(buf, C.int(c)), nil)
+		}))
+}
+
+// Close() is a wrapper around g_input_stream_close().
+func (v *InputStream) Close() error {
+	var err *C.GError = nil
+	c := C.g_input_stream_close(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+/*
+ * FileInputStream
+ */
+
+// FileInputStream is a representation of GIO's GFileInputStream.
+type FileInputStream struct {
+	InputStream
+}
+
+func fileInputStreamFromNative(c *C.GFileInputStream) *FileInputStream {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	s := &FileInputStream{InputStream{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return s
+}
+
+// Native() returns a pointer to the underlying GFileInputStream.
+func (v *FileInputStream) Native() *C.GFileInputStream {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GFileInputStream)(v.Ptr())
+}
+
+/*
+ * OutputStream
+ */
+
+// OutputStream is a representation of GIO's GOutputStream.  It implements
+// io.Writer and io.Closer using blocking writes.
+type OutputStream struct {
+	*glib.Object
+}
+
+func wrapOutputStream(obj *glib.Object) *OutputStream {
+	return &OutputStream{obj}
+}
+
+// Native() returns a pointer to the underlying GOutputStream.
+func (v *OutputStream) Native() *C.GOutputStream {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GOutputStream)(v.Ptr())
+}
+
+// Write() is a wrapper around g_output_stream_write_all().  An error is
+// returned if fewer than len(p) bytes are written.
+func (v *OutputStream) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	var (
+		written C.gsize
+		err     *C.GError = nil
+	)
+	c := C.g_output_stream_write_all(v.Native(), unsafe.Pointer(&p[0]),
+		C.gsize(len(p)), &written, nil, &err)
+	if !gobool(c) {
+		return int(written), goError(err)
+	}
+	return int(written), nil
+}
+
+// WriteAllAsync() is a wrapper around g_output_stream_write_all_async().
+// p is copied before WriteAllAsync() returns.  f is called from the main
+// loop once all of p is written, or with the number of bytes written
+// before an error occurred or ctx was done.
+func (v *OutputStream) WriteAllAsync(ctx context.Context, p []byte, f func(written int, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	buf := C.CBytes(p)
+	C.g_output_stream_write_all_async(v.Native(), buf, C.gsize(len(p)),
+		C.G_PRIORITY_DEFAULT, cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			defer C.free(buf)
+			var (
+				written C.gsize
+				err     *C.GError = nil
+			)
+			c := C.g_output_stream_write_all_finish(v.Native(), result,
+				&written, &err)
+			if !gobool(c) {
+				f(int(written), goContextError(ctx, err))
+				return
+			}
+			f(int(written), nil)
+		}))
+}
+
+// Flush() is a wrapper around g_output_stream_flush().
+func (v *OutputStream) Flush() error {
+	var err *C.GError = nil
+	c := C.g_output_stream_flush(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// Close() is a wrapper around g_output_stream_close().
+func (v *OutputStream) Close() error {
+	var err *C.GError = nil
+	c := C.g_output_stream_close(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+/*
+ * FileOutputStream
+ */
+
+// FileOutputStream is a representation of GIO's GFileOutputStream.
+type FileOutputStream struct {
+	OutputStream
+}
+
+func fileOutputStreamFromNative(c *C.GFileOutputStream) *FileOutputStream {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	s := &FileOutputStream{OutputStream{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return s
+}
+
+// Native() returns a pointer to the underlying GFileOutputStream.
+func (v *FileOutputStream) Native() *C.GFileOutputStream {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GFileOutputStream)(v.Ptr())
+}
+
+// GetEtag() is a wrapper around g_file_output_stream_get_etag().  It is
+// only available once the stream has been closed.
+func (v *FileOutputStream) GetEtag() string {
+	c := C.g_file_output_stream_get_etag(v.Native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString(c)
+}
+
+/*
+ * Subprocess
+ */
+
+type SubprocessFlags int
+
+const (
+	SUBPROCESS_FLAGS_NONE           SubprocessFlags = C.G_SUBPROCESS_FLAGS_NONE
+	SUBPROCESS_FLAGS_STDIN_PIPE                     = C.G_SUBPROCESS_FLAGS_STDIN_PIPE
+	SUBPROCESS_FLAGS_STDIN_INHERIT                  = C.G_SUBPROCESS_FLAGS_STDIN_INHERIT
+	SUBPROCESS_FLAGS_STDOUT_PIPE                    = C.G_SUBPROCESS_FLAGS_STDOUT_PIPE
+	SUBPROCESS_FLAGS_STDOUT_SILENCE                 = C.G_SUBPROCESS_FLAGS_STDOUT_SILENCE
+	SUBPROCESS_FLAGS_STDERR_PIPE                    = C.G_SUBPROCESS_FLAGS_STDERR_PIPE
+	SUBPROCESS_FLAGS_STDERR_SILENCE                 = C.G_SUBPROCESS_FLAGS_STDERR_SILENCE
+	SUBPROCESS_FLAGS_STDERR_MERGE                   = C.G_SUBPROCESS_FLAGS_STDERR_MERGE
+	SUBPROCESS_FLAGS_INHERIT_FDS                    = C.G_SUBPROCESS_FLAGS_INHERIT_FDS
+)
+
+// Subprocess is a representation of GIO's GSubprocess.
+type Subprocess struct {
+	*glib.Object
+}
+
+func wrapSubprocess(obj *glib.Object) *Subprocess {
+	return &Subprocess{obj}
+}
+
+// Native() returns a pointer to the underlying GSubprocess.
+func (v *Subprocess) Native() *C.GSubprocess {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSubprocess)(v.Ptr())
+}
+
+func subprocessFromNative(c *C.GSubprocess) *Subprocess {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	p := wrapSubprocess(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return p
+}
+
+// SubprocessNew() is a wrapper around g_subprocess_newv().  argv[0] is
+// looked up in PATH.
+func SubprocessNew(flags SubprocessFlags, argv ...string) (*Subprocess, error) {
+	if len(argv) == 0 {
+		return nil, emptyArgvErr
+	}
+	cargv := make([]*C.gchar, len(argv)+1)
+	for i, arg := range argv {
+		cargv[i] = (*C.gchar)(C.CString(arg))
+		defer C.free(unsafe.Pointer(cargv[i]))
+	}
+	var err *C.GError = nil
+	c := C.g_subprocess_newv(&cargv[0], C.GSubprocessFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return subprocessFromNative(c), nil
+}
+
+// GetIdentifier() is a wrapper around g_subprocess_get_identifier().  An
+// empty string is returned once the process has exited.
+func (v *Subprocess) GetIdentifier() string {
+	c := C.g_subprocess_get_identifier(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetStdinPipe() is a wrapper around g_subprocess_get_stdin_pipe().  A
+// non-nil error is returned unless the process was created with
+// SUBPROCESS_FLAGS_STDIN_PIPE.
+func (v *Subprocess) GetStdinPipe() (*OutputStream, error) {
+	c := C.g_subprocess_get_stdin_pipe(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapOutputStream(obj), nil
+}
+
+// GetStdoutPipe() is a wrapper around g_subprocess_get_stdout_pipe().  A
+// non-nil error is returned unless the process was created with
+// SUBPROCESS_FLAGS_STDOUT_PIPE.
+func (v *Subprocess) GetStdoutPipe() (*InputStream, error) {
+	c := C.g_subprocess_get_stdout_pipe(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapInputStream(obj), nil
+}
+
+// GetStderrPipe() is a wrapper around g_subprocess_get_stderr_pipe().  A
+// non-nil error is returned unless the process was created with
+// SUBPROCESS_FLAGS_STDERR_PIPE.
+func (v *Subprocess) GetStderrPipe() (*InputStream, error) {
+	c := C.g_subprocess_get_stderr_pipe(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapInputStream(obj), nil
+}
+
+// Wait() is a wrapper around g_subprocess_wait().  Cancelling ctx stops
+// the wait, but does not terminate the process.
+func (v *Subprocess) Wait(ctx context.Context) error {
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	var err *C.GError = nil
+	c := C.g_subprocess_wait(v.Native(), cancellable, &err)
+	if !gobool(c) {
+		return goContextError(ctx, err)
+	}
+	return nil
+}
+
+// WaitCheck() is a wrapper around g_subprocess_wait_check().  Unlike
+// Wait(), an error is also returned if the process did not exit
+// successfully.
+func (v *Subprocess) WaitCheck(ctx context.Context) error {
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	var err *C.GError = nil
+	c := C.g_subprocess_wait_check(v.Native(), cancellable, &err)
+	if !gobool(c) {
+		return goContextError(ctx, err)
+	}
+	return nil
+}
+
+// WaitAsync() is a wrapper around g_subprocess_wait_async().  f is called
+// from the main loop once the process exits or ctx is done.
+func (v *Subprocess) WaitAsync(ctx context.Context, f func(err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_subprocess_wait_async(v.Native(), cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_subprocess_wait_finish(v.Native(), result, &err)
+			if !gobool(c) {
+				f(goContextError(ctx, err))
+				return
+			}
+			f(nil)
+		}))
+}
+
+// WaitCheckAsync() is a wrapper around g_subprocess_wait_check_async().
+// f is called as for WaitAsync(), with an error if the process did not
+// exit successfully.
+func (v *Subprocess) WaitCheckAsync(ctx context.Context, f func(err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_subprocess_wait_check_async(v.Native(), cancellable,
+		asyncReadyCallback, asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_subprocess_wait_check_finish(v.Native(), result, &err)
+			if !gobool(c) {
+				f(goContextError(ctx, err))
+				return
+			}
+			f(nil)
+		}))
+}
+
+// CommunicateUTF8() is a wrapper around g_subprocess_communicate_utf8().
+// stdin is written to the process, which must have been created with
+// SUBPROCESS_FLAGS_STDIN_PIPE unless stdin is empty, and its output is
+// collected until it exits.  stdout and stderr are only collected if
+// piped.
+func (v *Subprocess) CommunicateUTF8(ctx context.Context, stdin string) (stdout, stderr string, err error) {
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	cstdin := cStringOrNil(stdin)
+	defer C.free(unsafe.Pointer(cstdin))
+	var cstdout, cstderr *C.char
+	var cerr *C.GError = nil
+	c := C.g_subprocess_communicate_utf8(v.Native(), (*C.char)(cstdin),
+		cancellable, &cstdout, &cstderr, &cerr)
+	if !gobool(c) {
+		return "", "", goContextError(ctx, cerr)
+	}
+	defer C.g_free(C.gpointer(cstdout))
+	defer C.g_free(C.gpointer(cstderr))
+	return C.GoString(cstdout), C.GoString(cstderr), nil
+}
+
+// CommunicateUTF8Async() is a wrapper around
+// g_subprocess_communicate_utf8_async().  f is called from the main loop
+// with the output of the process once it exits, or once ctx is done.
+func (v *Subprocess) CommunicateUTF8Async(ctx context.Context, stdin string, f func(stdout, stderr string, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	cstdin := cStringOrNil(stdin)
+	defer C.free(unsafe.Pointer(cstdin))
+	C.g_subprocess_communicate_utf8_async(v.Native(), (*C.char)(cstdin),
+		cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var cstdout, cstderr *C.char
+			var err *C.GError = nil
+			c := C.g_subprocess_communicate_utf8_finish(v.Native(), result,
+				&cstdout, &cstderr, &err)
+			if !gobool(c) {
+				f("", "", goContextError(ctx, err))
+				return
+			}
+			defer C.g_free(C.gpointer(cstdout))
+			defer C.g_free(C.gpointer(cstderr))
+			f(C.GoString(cstdout), C.GoString(cstderr), nil)
+		}))
+}
+
+// ForceExit() is a wrapper around g_subprocess_force_exit().
+func (v *Subprocess) ForceExit() {
+	C.g_subprocess_force_exit(v.Native())
+}
+
+// SendSignal() is a wrapper around g_subprocess_send_signal().
+func (v *Subprocess) SendSignal(signalNum int) {
+	C.g_subprocess_send_signal(v.Native(), C.gint(signalNum))
+}
+
+// GetSuccessful() is a wrapper around g_subprocess_get_successful().  It
+// may only be called once the process has exited.
+func (v *Subprocess) GetSuccessful() bool {
+	c := C.g_subprocess_get_successful(v.Native())
+	return gobool(c)
+}
+
+// GetIfExited() is a wrapper around g_subprocess_get_if_exited().
+func (v *Subprocess) GetIfExited() bool {
+	c := C.g_subprocess_get_if_exited(v.Native())
+	return gobool(c)
+}
+
+// GetExitStatus() is a wrapper around g_subprocess_get_exit_status().
+func (v *Subprocess) GetExitStatus() int {
+	c := C.g_subprocess_get_exit_status(v.Native())
+	return int(c)
+}
+
+// GetIfSignaled() is a wrapper around g_subprocess_get_if_signaled().
+func (v *Subprocess) GetIfSignaled() bool {
+	c := C.g_subprocess_get_if_signaled(v.Native())
+	return gobool(c)
+}
+
+// GetTermSig() is a wrapper around g_subprocess_get_term_sig().
+func (v *Subprocess) GetTermSig() int {
+	c := C.g_subprocess_get_term_sig(v.Native())
+	return int(c)
+}
+
+/*
+ * SubprocessLauncher
+ */
+
+// SubprocessLauncher is a representation of GIO's GSubprocessLauncher.
+type SubprocessLauncher struct {
+	*glib.Object
+}
+
+// Native() returns a pointer to the underlying GSubprocessLauncher.
+func (v *SubprocessLauncher) Native() *C.GSubprocessLauncher {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSubprocessLauncher)(v.Ptr())
+}
+
+// SubprocessLauncherNew() is a wrapper around
+// g_subprocess_launcher_new().  The launcher starts with a copy of the
+// environment of the current process.
+func SubprocessLauncherNew(flags SubprocessFlags) (*SubprocessLauncher, error) {
+	c := C.g_subprocess_launcher_new(C.GSubprocessFlags(flags))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	l := &SubprocessLauncher{obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return l, nil
+}
+
+// Spawn() is a wrapper around g_subprocess_launcher_spawnv().
+func (v *SubprocessLauncher) Spawn(argv ...string) (*Subprocess, error) {
+	if len(argv) == 0 {
+		return nil, emptyArgvErr
+	}
+	cargv := make([]*C.gchar, len(argv)+1)
+	for i, arg := range argv {
+		cargv[i] = (*C.gchar)(C.CString(arg))
+		defer C.free(unsafe.Pointer(cargv[i]))
+	}
+	var err *C.GError = nil
+	c := C.g_subprocess_launcher_spawnv(v.Native(), &cargv[0], &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return subprocessFromNative(c), nil
+}
+
+// SetFlags() is a wrapper around g_subprocess_launcher_set_flags().
+func (v *SubprocessLauncher) SetFlags(flags SubprocessFlags) {
+	C.g_subprocess_launcher_set_flags(v.Native(), C.GSubprocessFlags(flags))
+}
+
+// SetEnviron() is a wrapper around g_subprocess_launcher_set_environ().
+// env holds "NAME=value" pairs, as returned by os.Environ(), and replaces
+// the entire environment of processes spawned by the launcher.
+func (v *SubprocessLauncher) SetEnviron(env []string) {
+	cenv := make([]*C.gchar, len(env)+1)
+	for i, str := range env {
+		cenv[i] = (*C.gchar)(C.CString(str))
+		defer C.free(unsafe.Pointer(cenv[i]))
+	}
+	C.g_subprocess_launcher_set_environ(v.Native(), &cenv[0])
+}
+
+// Setenv() is a wrapper around g_subprocess_launcher_setenv().
+func (v *SubprocessLauncher) Setenv(variable, value string, overwrite bool) {
+	cvar := C.CString(variable)
+	defer C.free(unsafe.Pointer(cvar))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	C.g_subprocess_launcher_setenv(v.Native(), (*C.gchar)(cvar),
+		(*C.gchar)(cvalue), gbool(overwrite))
+}
+
+// Unsetenv() is a wrapper around g_subprocess_launcher_unsetenv().
+func (v *SubprocessLauncher) Unsetenv(variable string) {
+	cstr := C.CString(variable)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_subprocess_launcher_unsetenv(v.Native(), (*C.gchar)(cstr))
+}
+
+// Getenv() is a wrapper around g_subprocess_launcher_getenv().  The
+// second return value reports whether the variable is set.
+func (v *SubprocessLauncher) Getenv(variable string) (string, bool) {
+	cstr := C.CString(variable)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_subprocess_launcher_getenv(v.Native(), (*C.gchar)(cstr))
+	if c == nil {
+		return "", false
+	}
+	return C.GoString((*C.char)(c)), true
+}
+
+// SetCwd() is a wrapper around g_subprocess_launcher_set_cwd().
+func (v *SubprocessLauncher) SetCwd(cwd string) {
+	cstr := C.CString(cwd)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_subprocess_launcher_set_cwd(v.Native(), (*C.gchar)(cstr))
+}
+
+// SetStdinFilePath() is a wrapper around
+// g_subprocess_launcher_set_stdin_file_path().
+func (v *SubprocessLauncher) SetStdinFilePath(path string) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_subprocess_launcher_set_stdin_file_path(v.Native(),
+		(*C.gchar)(cstr))
+}
+
+// SetStdoutFilePath() is a wrapper around
+// g_subprocess_launcher_set_stdout_file_path().
+func (v *SubprocessLauncher) SetStdoutFilePath(path string) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_subprocess_launcher_set_stdout_file_path(v.Native(),
+		(*C.gchar)(cstr))
+}
+
+// SetStderrFilePath() is a wrapper around
+// g_subprocess_launcher_set_stderr_file_path().
+func (v *SubprocessLauncher) SetStderrFilePath(path string) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_subprocess_launcher_set_stderr_file_path(v.Native(),
+		(*C.gchar)(cstr))
+}
+
+/*
+ * SocketAddress
+ */
+
+// SocketAddress is a representation of GIO's GSocketAddress.
+type SocketAddress struct {
+	*glib.Object
+}
+
+// ISocketAddress is an interface type implemented by all structs
+// embedding a SocketAddress.  It is meant to be used as an argument type
+// for wrapper functions that wrap around a C function taking a
+// GSocketAddress.
+type ISocketAddress interface {
+	glib.IObject
+	toSocketAddress() *C.GSocketAddress
+}
+
+// Native() returns a pointer to the underlying GSocketAddress.
+func (v *SocketAddress) Native() *C.GSocketAddress {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSocketAddress)(v.Ptr())
+}
+
+func (v *SocketAddress) toSocketAddress() *C.GSocketAddress {
+	return v.Native()
+}
+
+// socketConnectable() returns address as a GSocketConnectable.
+func socketConnectable(address ISocketAddress) *C.GSocketConnectable {
+	return (*C.GSocketConnectable)(unsafe.Pointer(address.toSocketAddress()))
+}
+
+// goSocketAddress() wraps a GSocketAddress, owned by the caller, as an
+// *InetSocketAddress or *UnixSocketAddress.  nil is returned for NULL or
+// for addresses of any other family.
+func goSocketAddress(c *C.GSocketAddress) net.Addr {
+	if c == nil {
+		return nil
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	switch {
+	case gobool(C._g_is_inet_socket_address(c)):
+		return &InetSocketAddress{SocketAddress{obj}}
+	case gobool(C._g_is_unix_socket_address(c)):
+		return &UnixSocketAddress{SocketAddress{obj}}
+	}
+	return nil
+}
+
+/*
+ * InetSocketAddress
+ */
+
+// InetSocketAddress is a representation of GIO's GInetSocketAddress.  It
+// implements net.Addr.
+type InetSocketAddress struct {
+	SocketAddress
+}
+
+// Native() returns a pointer to the underlying GInetSocketAddress.
+func (v *InetSocketAddress) Native() *C.GInetSocketAddress {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GInetSocketAddress)(v.Ptr())
+}
+
+// InetSocketAddressNewFromString() is a wrapper around
+// g_inet_socket_address_new_from_string().  address must be an IPv4 or
+// IPv6 address; host names are not resolved.
+func InetSocketAddressNewFromString(address string, port uint) (*InetSocketAddress, error) {
+	cstr := C.CString(address)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_inet_socket_address_new_from_string((*C.char)(cstr),
+		C.guint(port))
+	if c == nil {
+		return nil, errors.New("invalid IP address: " + address)
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := &InetSocketAddress{SocketAddress{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return a, nil
+}
+
+// GetAddress() returns the string form of the address returned by
+// g_inet_socket_address_get_address().
+func (v *InetSocketAddress) GetAddress() string {
+	addr := C.g_inet_socket_address_get_address(v.Native())
+	c := C.g_inet_address_to_string(addr)
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// GetPort() is a wrapper around g_inet_socket_address_get_port().
+func (v *InetSocketAddress) GetPort() uint {
+	c := C.g_inet_socket_address_get_port(v.Native())
+	return uint(c)
+}
+
+// Network() returns "tcp".
+func (v *InetSocketAddress) Network() string {
+	return "tcp"
+}
+
+// String() returns the address and port in the form "host:port".
+func (v *InetSocketAddress) String() string {
+	return net.JoinHostPort(v.GetAddress(), strconv.Itoa(int(v.GetPort())))
+}
+
+/*
+ * UnixSocketAddress
+ */
+
+// UnixSocketAddress is a representation of GIO's GUnixSocketAddress.  It
+// implements net.Addr.
+type UnixSocketAddress struct {
+	SocketAddress
+}
+
+// Native() returns a pointer to the underlying GUnixSocketAddress.
+func (v *UnixSocketAddress) Native() *C.GUnixSocketAddress {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GUnixSocketAddress)(v.Ptr())
+}
+
+// UnixSocketAddressNew() is a wrapper around g_unix_socket_address_new().
+func UnixSocketAddressNew(path string) (*UnixSocketAddress, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_unix_socket_address_new((*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := &UnixSocketAddress{SocketAddress{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return a, nil
+}
+
+// GetPath() is a wrapper around g_unix_socket_address_get_path().  An
+// empty string is returned for unnamed sockets.
+func (v *UnixSocketAddress) GetPath() string {
+	c := C.g_unix_socket_address_get_path(v.Native())
+	n := C.g_unix_socket_address_get_path_len(v.Native())
+	return C.GoStringN((*C.char)(c), C.int(n))
+}
+
+// Network() returns "unix".
+func (v *UnixSocketAddress) Network() string {
+	return "unix"
+}
+
+// String() returns the socket path.
+func (v *UnixSocketAddress) String() string {
+	return v.GetPath()
+}
+
+/*
+ * SocketConnection
+ */
+
+// SocketConnection is a representation of GIO's GSocketConnection.  It
+// implements net.Conn using blocking reads and writes, which must not be
+// made from the main loop; ReadAsync() and WriteAllAsync() on the
+// connection's streams may be used there instead.
+type SocketConnection struct {
+	*glib.Object
+	readDeadline  *connDeadline
+	writeDeadline *connDeadline
+}
+
+func wrapSocketConnection(obj *glib.Object) *SocketConnection {
+	return &SocketConnection{
+		Object:        obj,
+		readDeadline:  &connDeadline{pending: map[*C.GCancellable]struct{}{}},
+		writeDeadline: &connDeadline{pending: map[*C.GCancellable]struct{}{}},
+	}
+}
+
+func socketConnectionFromNative(c *C.GSocketConnection) *SocketConnection {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	conn := wrapSocketConnection(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return conn
+}
+
+// Native() returns a pointer to the underlying GSocketConnection.
+func (v *SocketConnection) Native() *C.GSocketConnection {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSocketConnection)(v.Ptr())
+}
+
+func (v *SocketConnection) ioStream() *C.GIOStream {
+	return (*C.GIOStream)(v.Ptr())
+}
+
+// GetInputStream() is a wrapper around g_io_stream_get_input_stream().
+func (v *SocketConnection) GetInputStream() *InputStream {
+	c := C.g_io_stream_get_input_stream(v.ioStream())
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapInputStream(obj)
+}
+
+// GetOutputStream() is a wrapper around g_io_stream_get_output_stream().
+func (v *SocketConnection) GetOutputStream() *OutputStream {
+	c := C.g_io_stream_get_output_stream(v.ioStream())
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapOutputStream(obj)
+}
+
+// IsConnected() is a wrapper around g_socket_connection_is_connected().
+func (v *SocketConnection) IsConnected() bool {
+	c := C.g_socket_connection_is_connected(v.Native())
+	return gobool(c)
+}
+
+// connDeadline is a read or write deadline of a SocketConnection.  Each
+// blocking call registers its own GCancellable, which is cancelled when
+// the deadline passes, so a deadline also applies to calls that are
+// already blocked.
+type connDeadline struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	gen     uint64
+	expired bool
+	pending map[*C.GCancellable]struct{}
+}
+
+// set() sets the deadline to t, cancelling pending calls if t has
+// already passed.  A zero t disables the deadline.
+func (d *connDeadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.gen++
+	d.expired = false
+	if t.IsZero() {
+		return
+	}
+	timeout := time.Until(t)
+	if timeout <= 0 {
+		d.expire()
+		return
+	}
+	gen := d.gen
+	d.timer = time.AfterFunc(timeout, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.gen == gen {
+			d.expire()
+		}
+	})
+}
+
+// expire() marks the deadline as passed and cancels all pending calls.
+// d.mu must be held.
+func (d *connDeadline) expire() {
+	d.expired = true
+	for c := range d.pending {
+		C.g_cancellable_cancel(c)
+	}
+}
+
+// begin() returns a new GCancellable for a blocking call, or
+// os.ErrDeadlineExceeded if the deadline has already passed.  The
+// GCancellable must be passed to end() once the call returns.
+func (d *connDeadline) begin() (*C.GCancellable, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.expired {
+		return nil, os.ErrDeadlineExceeded
+	}
+	c := C.g_cancellable_new()
+	d.pending[c] = struct{}{}
+	return c, nil
+}
+
+// end() releases a GCancellable returned by begin() and reports whether
+// the deadline passed during the call.
+func (d *connDeadline) end(c *C.GCancellable) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.pending, c)
+	C.g_object_unref(C.gpointer(c))
+	return d.expired
+}
+
+// Read() reads from the connection's input stream.  Once the read
+// deadline passes, Read() fails with os.ErrDeadlineExceeded, and io.EOF
+// is returned once the peer closes the connection.
+func (v *SocketConnection) Read(p []byte) (int, error) {
+	cancellable, err := v.readDeadline.begin()
+	if err != nil {
+		return 0, err
+	}
+	if len(p) == 0 {
+		v.readDeadline.end(cancellable)
+		return 0, nil
+	}
+	var gerr *C.GError = nil
+	c := C.g_input_stream_read(C.g_io_stream_get_input_stream(v.ioStream()),
+		unsafe.Pointer(&p[0]), C.gsize(len(p)), cancellable, &gerr)
+	expired := v.readDeadline.end(cancellable)
+	if c < 0 {
+		if expired {
+			C.g_error_free(gerr)
+			return 0, os.ErrDeadlineExceeded
+		}
+		return 0, goError(gerr)
+	}
+	if c == 0 {
+		return 0, io.EOF
+	}
+	return int(c), nil
+}
+
+// Write() writes all of p to the connection's output stream.  Once the
+// write deadline passes, Write() fails with os.ErrDeadlineExceeded and
+// returns the number of bytes written so far.
+func (v *SocketConnection) Write(p []byte) (int, error) {
+	cancellable, err := v.writeDeadline.begin()
+	if err != nil {
+		return 0, err
+	}
+	if len(p) == 0 {
+		v.writeDeadline.end(cancellable)
+		return 0, nil
+	}
+	var (
+		written C.gsize
+		gerr    *C.GError = nil
+	)
+	c := C.g_output_stream_write_all(
+		C.g_io_stream_get_output_stream(v.ioStream()),
+		unsafe.Pointer(&p[0]), C.gsize(len(p)), &written, cancellable,
+		&gerr)
+	expired := v.writeDeadline.end(cancellable)
+	if !gobool(c) {
+		if expired {
+			C.g_error_free(gerr)
+			return int(written), os.ErrDeadlineExceeded
+		}
+		return int(written), goError(gerr)
+	}
+	return int(written), nil
+}
+
+// Close() is a wrapper around g_io_stream_close().
+func (v *SocketConnection) Close() error {
+	var err *C.GError = nil
+	c := C.g_io_stream_close(v.ioStream(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// LocalAddr() is a wrapper around g_socket_connection_get_local_address().
+// The result is an *InetSocketAddress or *UnixSocketAddress, or nil if the
+// address is unavailable.
+func (v *SocketConnection) LocalAddr() net.Addr {
+	c := C.g_socket_connection_get_local_address(v.Native(), nil)
+	return goSocketAddress(c)
+}
+
+// RemoteAddr() is a wrapper around
+// g_socket_connection_get_remote_address().  The result is as for
+// LocalAddr().
+func (v *SocketConnection) RemoteAddr() net.Addr {
+	c := C.g_socket_connection_get_remote_address(v.Native(), nil)
+	return goSocketAddress(c)
+}
+
+// SetDeadline() sets both the read and write deadlines.
+func (v *SocketConnection) SetDeadline(t time.Time) error {
+	v.readDeadline.set(t)
+	v.writeDeadline.set(t)
+	return nil
+}
+
+// SetReadDeadline() sets the time after which Read() fails with
+// os.ErrDeadlineExceeded, including a Read() that is already blocked.  A
+// zero value disables the deadline.
+func (v *SocketConnection) SetReadDeadline(t time.Time) error {
+	v.readDeadline.set(t)
+	return nil
+}
+
+// SetWriteDeadline() sets the time after which Write() fails with
+// os.ErrDeadlineExceeded, including a Write() that is already blocked.
+// A zero value disables the deadline.
+func (v *SocketConnection) SetWriteDeadline(t time.Time) error {
+	v.writeDeadline.set(t)
+	return nil
+}
+
+/*
+ * SocketClient
+ */
+
+// SocketClient is a representation of GIO's GSocketClient.
+type SocketClient struct {
+	*glib.Object
+}
+
+// Native() returns a pointer to the underlying GSocketClient.
+func (v *SocketClient) Native() *C.GSocketClient {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSocketClient)(v.Ptr())
+}
+
+// SocketClientNew() is a wrapper around g_socket_client_new().
+func SocketClientNew() (*SocketClient, error) {
+	c := C.g_socket_client_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	client := &SocketClient{obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return client, nil
+}
+
+// SetTimeout() is a wrapper around g_socket_client_set_timeout().  The
+// timeout applies to connecting and to all I/O on the resulting
+// connections.  GIO only supports whole seconds, so timeout is rounded up
+// to the next second.  Zero means no timeout.
+func (v *SocketClient) SetTimeout(timeout time.Duration) {
+	var secs time.Duration
+	if timeout > 0 {
+		secs = (timeout + time.Second - 1) / time.Second
+	}
+	C.g_socket_client_set_timeout(v.Native(), C.guint(secs))
+}
+
+// GetTimeout() is a wrapper around g_socket_client_get_timeout().
+func (v *SocketClient) GetTimeout() time.Duration {
+	c := C.g_socket_client_get_timeout(v.Native())
+	return time.Duration(c) * time.Second
+}
+
+// Connect() is a wrapper around g_socket_client_connect().
+func (v *SocketClient) Connect(ctx context.Context, address ISocketAddress) (*SocketConnection, error) {
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	var err *C.GError = nil
+	c := C.g_socket_client_connect(v.Native(), socketConnectable(address),
+		cancellable, &err)
+	if c == nil {
+		return nil, goContextError(ctx, err)
+	}
+	return socketConnectionFromNative(c), nil
+}
+
+// ConnectAsync() is a wrapper around g_socket_client_connect_async().  f
+// is called from the main loop once the connection is made or fails, or
+// ctx is done.
+func (v *SocketClient) ConnectAsync(ctx context.Context, address ISocketAddress, f func(conn *SocketConnection, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	C.g_socket_client_connect_async(v.Native(), socketConnectable(address),
+		cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_socket_client_connect_finish(v.Native(), result, &err)
+			if c == nil {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			f(socketConnectionFromNative(c), nil)
+		}))
+}
+
+// ConnectToHost() is a wrapper around g_socket_client_connect_to_host().
+// hostAndPort may include a port, as in "localhost:8080", in which case
+// defaultPort is ignored.
+func (v *SocketClient) ConnectToHost(ctx context.Context, hostAndPort string, defaultPort uint16) (*SocketConnection, error) {
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	cstr := C.CString(hostAndPort)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_socket_client_connect_to_host(v.Native(), (*C.gchar)(cstr),
+		C.guint16(defaultPort), cancellable, &err)
+	if c == nil {
+		return nil, goContextError(ctx, err)
+	}
+	return socketConnectionFromNative(c), nil
+}
+
+// ConnectToHostAsync() is a wrapper around
+// g_socket_client_connect_to_host_async().  f is called as for
+// ConnectAsync().
+func (v *SocketClient) ConnectToHostAsync(ctx context.Context, hostAndPort string, defaultPort uint16, f func(conn *SocketConnection, err error)) {
+	cancellable, release := cancellableFromContext(ctx)
+	cstr := C.CString(hostAndPort)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_socket_client_connect_to_host_async(v.Native(), (*C.gchar)(cstr),
+		C.guint16(defaultPort), cancellable, asyncReadyCallback,
+		asyncReady(func(result *C.GAsyncResult) {
+			defer release()
+			var err *C.GError = nil
+			c := C.g_socket_client_connect_to_host_finish(v.Native(),
+				result, &err)
+			if c == nil {
+				f(nil, goContextError(ctx, err))
+				return
+			}
+			f(socketConnectionFromNative(c), nil)
+		}))
+}
+
+/*
+ * SocketListener
+ */
+
+// SocketListener is a representation of GIO's GSocketListener.
+type SocketListener struct {
+	*glib.Object
+}
+
+// Native() returns a pointer to the underlying GSocketListener.
+func (v *SocketListener) Native() *C.GSocketListener {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSocketListener)(v.Ptr())
+}
+
+// AddInetPort() is a wrapper around g_socket_listener_add_inet_port().
+// The listener accepts connections on port for all local IPv4 and IPv6
+// addresses.
+func (v *SocketListener) AddInetPort(port uint16) error {
+	var err *C.GError = nil
+	c := C.g_socket_listener_add_inet_port(v.Native(), C.guint16(port),
+		nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// AddAnyInetPort() is a wrapper around
+// g_socket_listener_add_any_inet_port().  The chosen port is returned.
+func (v *SocketListener) AddAnyInetPort() (uint16, error) {
+	var err *C.GError = nil
+	c := C.g_socket_listener_add_any_inet_port(v.Native(), nil, &err)
+	if c == 0 {
+		return 0, goError(err)
+	}
+	return uint16(c), nil
+}
+
+// AddAddress() is a wrapper around g_socket_listener_add_address(),
+// listening for stream connections on address.  The address actually
+// bound is returned, which differs from address if it had port 0.
+func (v *SocketListener) AddAddress(address ISocketAddress) (net.Addr, error) {
+	var (
+		effective *C.GSocketAddress
+		err       *C.GError = nil
+	)
+	c := C.g_socket_listener_add_address(v.Native(),
+		address.toSocketAddress(), C.G_SOCKET_TYPE_STREAM,
+		C.G_SOCKET_PROTOCOL_DEFAULT, nil, &effective, &err)
+	if !gobool(c) {
+		return nil, goError(err)
+	}
+	return goSocketAddress(effective), nil
+}
+
+// Close() is a wrapper around g_socket_listener_close().
+func (v *SocketListener) Close() {
+	C.g_socket_listener_close(v.Native())
+}
+
+/*
+ * SocketService
+ */
+
+// SocketService is a representation of GIO's GSocketService.  Incoming
+// connections are dispatched from the main loop to handlers connected
+// with OnIncoming().
+type SocketService struct {
+	SocketListener
+}
+
+// Native() returns a pointer to the underlying GSocketService.
+func (v *SocketService) Native() *C.GSocketService {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSocketService)(v.Ptr())
+}
+
+// SocketServiceNew() is a wrapper around g_socket_service_new().  The
+// service is active once created, and starts accepting connections as
+// soon as addresses are added to it.
+func SocketServiceNew() (*SocketService, error) {
+	c := C.g_socket_service_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	s := &SocketService{SocketListener{obj}}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return s, nil
+}
+
+// Start() is a wrapper around g_socket_service_start().
+func (v *SocketService) Start() {
+	C.g_socket_service_start(v.Native())
+}
+
+// Stop() is a wrapper around g_socket_service_stop().
+func (v *SocketService) Stop() {
+	C.g_socket_service_stop(v.Native())
+}
+
+// IsActive() is a wrapper around g_socket_service_is_active().
+func (v *SocketService) IsActive() bool {
+	c := C.g_socket_service_is_active(v.Native())
+	return gobool(c)
+}
+
+// OnIncoming() connects f to the service's "incoming" signal.  f should
+// return true if it handled the connection, stopping other handlers from
+// being called.  The connection stays open for as long as it is
+// referenced from Go or until it is closed.
+func (v *SocketService) OnIncoming(f func(conn *SocketConnection) bool) glib.SignalHandle {
+	return v.Connect("incoming", func(_, conn *glib.Object) bool {
+		conn.Ref()
+		runtime.SetFinalizer(conn, (*glib.Object).Unref)
+		return f(wrapSocketConnection(conn))
+	})
+}
+
+/*
+ * Resource
+ */
+
+type ResourceFlags int
+
+const (
+	RESOURCE_FLAGS_NONE       ResourceFlags = C.G_RESOURCE_FLAGS_NONE
+	RESOURCE_FLAGS_COMPRESSED               = C.G_RESOURCE_FLAGS_COMPRESSED
+)
+
+type ResourceLookupFlags int
+
+const (
+	RESOURCE_LOOKUP_FLAGS_NONE ResourceLookupFlags = C.G_RESOURCE_LOOKUP_FLAGS_NONE
+)
+
+// Resource is a representation of GIO's GResource.
+type Resource struct {
+	ptr *C.GResource
+}
+
+// Native() returns a pointer to the underlying GResource.
+func (v *Resource) Native() *C.GResource {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+func wrapResource(c *C.GResource) *Resource {
+	r := &Resource{c}
+	runtime.SetFinalizer(r, (*Resource).unref)
+	return r
+}
+
+func (v *Resource) unref() {
+	C.g_resource_unref(v.ptr)
+}
+
+// ResourceLoad() is a wrapper around g_resource_load().  filename names a
+// resource bundle compiled with glib-compile-resources.
+func ResourceLoad(filename string) (*Resource, error) {
+	cstr := C.CString(filename)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resource_load((*C.gchar)(cstr), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return wrapResource(c), nil
+}
+
+// ResourceNewFromData() is a wrapper around g_resource_new_from_data().
+// data is copied, and may be modified once ResourceNewFromData() returns.
+func ResourceNewFromData(data []byte) (*Resource, error) {
+	var p unsafe.Pointer
+	if len(data) > 0 {
+		p = unsafe.Pointer(&data[0])
+	}
+	bytes := C.g_bytes_new(C.gconstpointer(p), C.gsize(len(data)))
+	defer C.g_bytes_unref(bytes)
+	var err *C.GError = nil
+	c := C.g_resource_new_from_data(bytes, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return wrapResource(c), nil
+}
+
+// ResourceNewFromFS() creates a Resource holding every regular file in
+// fsys, without compiling a bundle with glib-compile-resources.  Each
+// file's resource path is its path in fsys joined to prefix, which should
+// begin with a slash.  This allows files embedded with go:embed to be
+// used wherever GTK accepts a resource path:
+//
+//	//go:embed ui
+//	var ui embed.FS
+//
+//	res, err := gio.ResourceNewFromFS(ui, "/com/example/app")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	res.Register()
+//	builder.AddFromResource("/com/example/app/ui/main.ui")
+func ResourceNewFromFS(fsys fs.FS, prefix string) (*Resource, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		files[path.Join("/", prefix, name)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ResourceNewFromData(resourceData(files))
+}
+
+// Register() is a wrapper around g_resources_register().
+func (v *Resource) Register() {
+	C.g_resources_register(v.Native())
+}
+
+// Unregister() is a wrapper around g_resources_unregister().
+func (v *Resource) Unregister() {
+	C.g_resources_unregister(v.Native())
+}
+
+// LookupData() is a wrapper around g_resource_lookup_data().
+func (v *Resource) LookupData(path string, flags ResourceLookupFlags) ([]byte, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resource_lookup_data(v.Native(), (*C.char)(cstr),
+		C.GResourceLookupFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return goBytes(c), nil
+}
+
+// OpenStream() is a wrapper around g_resource_open_stream().
+func (v *Resource) OpenStream(path string, flags ResourceLookupFlags) (*InputStream, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resource_open_stream(v.Native(), (*C.char)(cstr),
+		C.GResourceLookupFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return inputStreamFromNative(c), nil
+}
+
+// EnumerateChildren() is a wrapper around g_resource_enumerate_children().
+// The names of child directories end with a slash.
+func (v *Resource) EnumerateChildren(path string, flags ResourceLookupFlags) ([]string, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resource_enumerate_children(v.Native(), (*C.char)(cstr),
+		C.GResourceLookupFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	defer C.g_strfreev(c)
+	return goStrv(c), nil
+}
+
+// ResourcesLookupData() is a wrapper around g_resources_lookup_data().
+func ResourcesLookupData(path string, flags ResourceLookupFlags) ([]byte, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resources_lookup_data((*C.char)(cstr),
+		C.GResourceLookupFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return goBytes(c), nil
+}
+
+// ResourcesOpenStream() is a wrapper around g_resources_open_stream().
+func ResourcesOpenStream(path string, flags ResourceLookupFlags) (*InputStream, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resources_open_stream((*C.char)(cstr),
+		C.GResourceLookupFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return inputStreamFromNative(c), nil
+}
+
+// ResourcesEnumerateChildren() is a wrapper around
+// g_resources_enumerate_children().
+func ResourcesEnumerateChildren(path string, flags ResourceLookupFlags) ([]string, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_resources_enumerate_children((*C.char)(cstr),
+		C.GResourceLookupFlags(flags), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	defer C.g_strfreev(c)
+	return goStrv(c), nil
+}
+
+// goBytes() copies the contents of a GBytes to a Go slice and releases
+// the caller's reference to it.
+func goBytes(bytes *C.GBytes) []byte {
+	defer C.g_bytes_unref(bytes)
+	var size C.gsize
+	p := C.g_bytes_get_data(bytes, &size)
+	return C.GoBytes(unsafe.Pointer(p), C.int(size))
+}
+
+// inputStreamFromNative() wraps a GInputStream returned with a full
+// reference.
+func inputStreamFromNative(c *C.GInputStream) *InputStream {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	s := wrapInputStream(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return s
+}
+
+// resourceItem is an entry in the hash table written by resourceData().
+// The keys of directory entries end with a slash.
+type resourceItem struct {
+	key      string
+	hash     uint32
+	index    uint32
+	parent   *resourceItem
+	children []*resourceItem
+	data     []byte
+}
+
+// resourceData() serializes files, keyed by absolute resource path, in
+// the GVDB format read by g_resource_new_from_data().  The layout matches
+// that written by glib-compile-resources: each file is stored under its
+// full path, and each directory, with a trailing slash, lists its
+// children.  Keys are stored relative to their parent directory.  All
+// data is written little-endian, which GVDB readers on big-endian hosts
+// detect and byteswap.
+func resourceData(files map[string][]byte) []byte {
+	items := make(map[string]*resourceItem)
+	var dir func(key string) *resourceItem
+	dir = func(key string) *resourceItem {
+		if item, ok := items[key]; ok {
+			return item
+		}
+		item := &resourceItem{key: key}
+		items[key] = item
+		if key != "/" {
+			parent := key[:strings.LastIndex(key[:len(key)-1], "/")+1]
+			item.parent = dir(parent)
+			item.parent.children = append(item.parent.children, item)
+		}
+		return item
+	}
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		item := &resourceItem{key: p, data: files[p]}
+		items[p] = item
+		item.parent = dir(p[:strings.LastIndex(p, "/")+1])
+		item.parent.children = append(item.parent.children, item)
+	}
+
+	// Items are ordered by hash bucket, with one bucket per item.
+	table := make([]*resourceItem, 0, len(items))
+	for _, item := range items {
+		item.hash = djbHash(item.key)
+		table = append(table, item)
+	}
+	nBuckets := uint32(len(table))
+	sort.Slice(table, func(i, j int) bool {
+		bi, bj := table[i].hash%nBuckets, table[j].hash%nBuckets
+		if bi != bj {
+			return bi < bj
+		}
+		return table[i].key < table[j].key
+	})
+	for i, item := range table {
+		item.index = uint32(i)
+	}
+
+	var (
+		le  = binary.LittleEndian
+		buf = make([]byte, 24)
+	)
+	allocate := func(alignment, size int) int {
+		start := (len(buf) + alignment - 1) &^ (alignment - 1)
+		buf = append(buf, make([]byte, start-len(buf)+size)...)
+		return start
+	}
+
+	// Header: signature, version, options and the root table pointer.
+	copy(buf, "GVariant")
+	root := allocate(4, 8+4*len(table)+24*len(table))
+	le.PutUint32(buf[16:], uint32(root))
+	le.PutUint32(buf[20:], uint32(len(buf)))
+
+	// Hash table header, with no bloom filter, and the bucket offsets.
+	le.PutUint32(buf[root:], 0)
+	le.PutUint32(buf[root+4:], nBuckets)
+	buckets := root + 8
+	for b, i := uint32(0), 0; b < nBuckets; b++ {
+		for i < len(table) && table[i].hash%nBuckets < b {
+			i++
+		}
+		le.PutUint32(buf[buckets+4*int(b):], uint32(i))
+	}
+	entries := buckets + 4*len(table)
+
+	for i, item := range table {
+		key := item.key
+		parent := uint32(0xffffffff)
+		if item.parent != nil {
+			key = key[len(item.parent.key):]
+			parent = item.parent.index
+		}
+		keyStart := allocate(1, len(key))
+		copy(buf[keyStart:], key)
+
+		var typ byte
+		var start, end int
+		if !strings.HasSuffix(item.key, "/") {
+			value := resourceValue(item.data)
+			typ = 'v'
+			start = allocate(8, len(value))
+			end = start + len(value)
+			copy(buf[start:], value)
+		} else {
+			typ = 'L'
+			start = allocate(4, 4*len(item.children))
+			end = start + 4*len(item.children)
+			for j, child := range item.children {
+				le.PutUint32(buf[start+4*j:], child.index)
+			}
+		}
+
+		entry := buf[entries+24*i:]
+		le.PutUint32(entry[0:], item.hash)
+		le.PutUint32(entry[4:], parent)
+		le.PutUint32(entry[8:], uint32(keyStart))
+		le.PutUint16(entry[12:], uint16(len(key)))
+		entry[14] = typ
+		le.PutUint32(entry[16:], uint32(start))
+		le.PutUint32(entry[20:], uint32(end))
+	}
+	return buf
+}
+
+// resourceValue() serializes the GVariant of type "v" holding a
+// "(uuay)" tuple of size, flags and data that GResource stores for each
+// uncompressed file.  As with glib-compile-resources, the data is
+// followed by a nul byte that is not counted in its size.
+func resourceValue(data []byte) []byte {
+	v := make([]byte, 8, 8+len(data)+len("\x00\x00(uuay)"))
+	binary.LittleEndian.PutUint32(v[0:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(v[4:], uint32(RESOURCE_FLAGS_NONE))
+	v = append(v, data...)
+	return append(v, "\x00\x00(uuay)"...)
+}
+
+// djbHash() is the string hash used by GVDB.
+func djbHash(key string) uint32 {
+	hash := uint32(5381)
+	for i := 0; i < len(key); i++ {
+		hash = hash*33 + uint32(int8(key[i]))
+	}
+	return hash
+}
+
+/*
+ * DBus
+ */
+
+type BusType int
+
+const (
+	BUS_TYPE_STARTER BusType = C.G_BUS_TYPE_STARTER
+	BUS_TYPE_NONE            = C.G_BUS_TYPE_NONE
+	BUS_TYPE_SYSTEM          = C.G_BUS_TYPE_SYSTEM
+	BUS_TYPE_SESSION         = C.G_BUS_TYPE_SESSION
+)
+
+type BusNameOwnerFlags int
+
+const (
+	BUS_NAME_OWNER_FLAGS_NONE              BusNameOwnerFlags = C.G_BUS_NAME_OWNER_FLAGS_NONE
+	BUS_NAME_OWNER_FLAGS_ALLOW_REPLACEMENT                   = C.G_BUS_NAME_OWNER_FLAGS_ALLOW_REPLACEMENT
+	BUS_NAME_OWNER_FLAGS_REPLACE                             = C.G_BUS_NAME_OWNER_FLAGS_REPLACE
+)
+
+type DBusConnectionFlags int
+
+const (
+	DBUS_CONNECTION_FLAGS_NONE                           DBusConnectionFlags = C.G_DBUS_CONNECTION_FLAGS_NONE
+	DBUS_CONNECTION_FLAGS_AUTHENTICATION_CLIENT                              = C.G_DBUS_CONNECTION_FLAGS_AUTHENTICATION_CLIENT
+	DBUS_CONNECTION_FLAGS_AUTHENTICATION_SERVER                              = C.G_DBUS_CONNECTION_FLAGS_AUTHENTICATION_SERVER
+	DBUS_CONNECTION_FLAGS_AUTHENTICATION_ALLOW_ANONYMOUS                     = C.G_DBUS_CONNECTION_FLAGS_AUTHENTICATION_ALLOW_ANONYMOUS
+	DBUS_CONNECTION_FLAGS_MESSAGE_BUS_CONNECTION                             = C.G_DBUS_CONNECTION_FLAGS_MESSAGE_BUS_CONNECTION
+	DBUS_CONNECTION_FLAGS_DELAY_MESSAGE_PROCESSING                           = C.G_DBUS_CONNECTION_FLAGS_DELAY_MESSAGE_PROCESSING
+)
+
+type DBusSignalFlags int
+
+const (
+	DBUS_SIGNAL_FLAGS_NONE                 DBusSignalFlags = C.G_DBUS_SIGNAL_FLAGS_NONE
+	DBUS_SIGNAL_FLAGS_NO_MATCH_RULE                        = C.G_DBUS_SIGNAL_FLAGS_NO_MATCH_RULE
+	DBUS_SIGNAL_FLAGS_MATCH_ARG0_NAMESPACE                 = C.G_DBUS_SIGNAL_FLAGS_MATCH_ARG0_NAMESPACE
+	DBUS_SIGNAL_FLAGS_MATCH_ARG0_PATH                      = C.G_DBUS_SIGNAL_FLAGS_MATCH_ARG0_PATH
+)
+
+type BusNameWatcherFlags int
+
+const (
+	BUS_NAME_WATCHER_FLAGS_NONE       BusNameWatcherFlags = C.G_BUS_NAME_WATCHER_FLAGS_NONE
+	BUS_NAME_WATCHER_FLAGS_AUTO_START                     = C.G_BUS_NAME_WATCHER_FLAGS_AUTO_START
+)
+
+type DBusProxyFlags int
+
+const (
+	DBUS_PROXY_FLAGS_NONE                       DBusProxyFlags = C.G_DBUS_PROXY_FLAGS_NONE
+	DBUS_PROXY_FLAGS_DO_NOT_LOAD_PROPERTIES                    = C.G_DBUS_PROXY_FLAGS_DO_NOT_LOAD_PROPERTIES
+	DBUS_PROXY_FLAGS_DO_NOT_CONNECT_SIGNALS                    = C.G_DBUS_PROXY_FLAGS_DO_NOT_CONNECT_SIGNALS
+	DBUS_PROXY_FLAGS_DO_NOT_AUTO_START                         = C.G_DBUS_PROXY_FLAGS_DO_NOT_AUTO_START
+	DBUS_PROXY_FLAGS_GET_INVALIDATED_PROPERTIES                = C.G_DBUS_PROXY_FLAGS_GET_INVALIDATED_PROPERTIES
+)
+
+type BusCallback func(conn *DBusConnection, name string)
+
+func (callback BusCallback) wrap() func(*glib.Object, string) {
+	return func(obj *glib.Object, name string) {
+		var conn *DBusConnection
+		if obj.Ptr() != nil {
+			conn = wrapDBusConnection(obj)
+			obj.Ref()
+			runtime.SetFinalizer(obj, (*glib.Object).Unref)
+		}
+		callback(conn, name)
+	}
+}
+
+// closure() returns a new GClosure calling callback, or nil if callback is
+// nil.
+func (callback BusCallback) closure() *C.GClosure {
+	if callback == nil {
+		return nil
+	}
+	return (*C.GClosure)(unsafe.Pointer(glib.ClosureNew(callback.wrap())))
+}
+
+// BusNameAppearedCallback is the type of functions called when a name
+// watched with BusWatchName() gains an owner.
+type BusNameAppearedCallback func(conn *DBusConnection, name, nameOwner string)
+
+// closure() returns a new GClosure calling callback, or nil if callback is
+// nil.
+func (callback BusNameAppearedCallback) closure() *C.GClosure {
+	if callback == nil {
+		return nil
+	}
+	return (*C.GClosure)(unsafe.Pointer(glib.ClosureNew(func(obj *glib.Object, name, nameOwner string) {
+		conn := wrapDBusConnection(obj)
+		obj.Ref()
+		runtime.SetFinalizer(obj, (*glib.Object).Unref)
+		callback(conn, name, nameOwner)
+	})))
+}
+
+type BusNameHandle uint
+
+func (h BusNameHandle) Unown() {
+	C.g_bus_unown_name(C.guint(h))
+}
+
+// BusOwnName() is a wrapper around g_bus_own_name_with_closures(). One of the following three situations is guaranteed:
+//   * nameLost() is called with a null connection
+//   * busAcquired(), then nameLost() are called
+//   * busAcquired(), then nameAcquired() are called
+// For more information see https://developer.gnome.org/gio/stable/gio-Owning-Bus-Names.html#g-bus-own-name
+func BusOwnName(typ BusType, name string, flags BusNameOwnerFlags, busAcquired BusCallback, nameAcquired BusCallback, nameLost BusCallback) BusNameHandle {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	h := C.g_bus_own_name_with_closures(C.GBusType(typ), (*C.gchar)(cstr), C.GBusNameOwnerFlags(flags),
+		busAcquired.closure(), nameAcquired.closure(), nameLost.closure())
+	return BusNameHandle(h)
+}
+
+type BusWatcherHandle uint
+
+func (h BusWatcherHandle) Unwatch() {
+	C.g_bus_unwatch_name(C.guint(h))
+}
+
+// BusWatchName() is a wrapper around g_bus_watch_name_with_closures().
+// nameAppeared() is called when name gains an owner, and nameVanished()
+// when it loses one, or with a nil connection if the bus cannot be
+// connected to.  One of them is called soon after the watch is started.
+// Either callback may be nil.
+func BusWatchName(typ BusType, name string, flags BusNameWatcherFlags, nameAppeared BusNameAppearedCallback, nameVanished BusCallback) BusWatcherHandle {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	h := C.g_bus_watch_name_with_closures(C.GBusType(typ), (*C.gchar)(cstr),
+		C.GBusNameWatcherFlags(flags), nameAppeared.closure(),
+		nameVanished.closure())
+	return BusWatcherHandle(h)
+}
+
+// DBusError is returned by DBusConnection methods when a D-Bus peer
+// replies with an error.
+type DBusError struct {
+	// Name is the D-Bus error name, such as
+	// "org.freedesktop.DBus.Error.ServiceUnknown".
+	Name string
+
+	// Message is the human-readable error message.
+	Message string
+}
+
+func (e *DBusError) Error() string {
+	return e.Name + ": " + e.Message
+}
+
+// goDBusError() converts a GError to a Go error and frees the GError.  If
+// ctx is done, its error is returned instead.  Errors carrying a remote
+// D-Bus error name are returned as a *DBusError.
+func goDBusError(ctx context.Context, err *C.GError) error {
+	if ctx != nil && ctx.Err() != nil {
+		C.g_error_free(err)
+		return ctx.Err()
+	}
+	c := C.g_dbus_error_get_remote_error(err)
+	if c == nil {
+		return goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	defer C.g_error_free(err)
+	C.g_dbus_error_strip_remote_error(err)
+	return &DBusError{
+		Name:    C.GoString((*C.char)(c)),
+		Message: C.GoString((*C.char)(C.error_get_message(err))),
+	}
+}
+
+// DBusConnection is a representation of GIO's GDBusConnection.
+type DBusConnection struct {
+	*glib.Object
+}
+
+func wrapDBusConnection(obj *glib.Object) *DBusConnection {
+	return &DBusConnection{obj}
+}
+
+// Native() returns a pointer to the underlying GDBusConnection.
+func (v *DBusConnection) Native() *C.GDBusConnection {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GDBusConnection)(v.Ptr())
+}
+
+// BusGet() is a wrapper around g_bus_get_sync().  The connection is
+// shared with all other users of the same bus in the process.
+func BusGet(typ BusType) (*DBusConnection, error) {
+	var err *C.GError = nil
+	c := C.g_bus_get_sync(C.GBusType(typ), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	conn := wrapDBusConnection(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return conn, nil
+}
+
+// DBusConnectionNewForAddress() is a wrapper around
+// g_dbus_connection_new_for_address_sync().  To connect to a message bus,
+// such as one started with dbus-daemon, pass
+// DBUS_CONNECTION_FLAGS_AUTHENTICATION_CLIENT and
+// DBUS_CONNECTION_FLAGS_MESSAGE_BUS_CONNECTION.
+func DBusConnectionNewForAddress(address string, flags DBusConnectionFlags) (*DBusConnection, error) {
+	cstr := C.CString(address)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_dbus_connection_new_for_address_sync((*C.gchar)(cstr),
+		C.GDBusConnectionFlags(flags), nil, nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	conn := wrapDBusConnection(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return conn, nil
+}
+
+// OwnName() is a wrapper around g_bus_own_name_on_connection_with_closures().
+// It is like BusOwnName(), but uses an existing connection.
+func (v *DBusConnection) OwnName(name string, flags BusNameOwnerFlags, nameAcquired BusCallback, nameLost BusCallback) BusNameHandle {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	h := C.g_bus_own_name_on_connection_with_closures(v.Native(),
+		(*C.gchar)(cstr), C.GBusNameOwnerFlags(flags),
+		nameAcquired.closure(), nameLost.closure())
+	return BusNameHandle(h)
+}
+
+// WatchName() is a wrapper around
+// g_bus_watch_name_on_connection_with_closures().  It is like
+// BusWatchName(), but uses an existing connection.
+func (v *DBusConnection) WatchName(name string, flags BusNameWatcherFlags, nameAppeared BusNameAppearedCallback, nameVanished BusCallback) BusWatcherHandle {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	h := C.g_bus_watch_name_on_connection_with_closures(v.Native(),
+		(*C.gchar)(cstr), C.GBusNameWatcherFlags(flags),
+		nameAppeared.closure(), nameVanished.closure())
+	return BusWatcherHandle(h)
+}
+
+// GetUniqueName() is a wrapper around g_dbus_connection_get_unique_name().
+func (v *DBusConnection) GetUniqueName() string {
+	c := C.g_dbus_connection_get_unique_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// IsClosed() is a wrapper around g_dbus_connection_is_closed().
+func (v *DBusConnection) IsClosed() bool {
+	c := C.g_dbus_connection_is_closed(v.Native())
+	return gobool(c)
+}
+
+// Close() is a wrapper around g_dbus_connection_close_sync().
+func (v *DBusConnection) Close() error {
+	var err *C.GError = nil
+	c := C.g_dbus_connection_close_sync(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// dbusTimeout() returns the timeout in milliseconds for a D-Bus call made
+// with ctx: -1 for the default timeout if ctx has no deadline, and
+// G_MAXINT, meaning no timeout, if the deadline is too far away to fit.
+func dbusTimeout(ctx context.Context) C.gint {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return -1
+	}
+	ms := time.Until(deadline) / time.Millisecond
+	if ms < 1 {
+		return 1
+	}
+	if ms > C.G_MAXINT {
+		return C.G_MAXINT
+	}
+	return C.gint(ms)
+}
+
+// Call() is a wrapper around g_dbus_connection_call_sync().  parameters
+// must be a tuple, or nil if the method takes no arguments, and the reply
+// is returned as a tuple.  The call blocks until a reply is received or
+// ctx is done, so it should not be made from the main loop's thread.  If
+// ctx has a deadline, it is used as the call's timeout; otherwise the
+// default D-Bus timeout applies.  Errors returned by the peer are
+// returned as a *DBusError.
+func (v *DBusConnection) Call(ctx context.Context, busName, objectPath, interfaceName, methodName string, parameters *glib.Variant) (*glib.Variant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	timeout := dbusTimeout(ctx)
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	cname := cStringOrNil(busName)
+	defer C.free(unsafe.Pointer(cname))
+	cpath := C.CString(objectPath)
+	defer C.free(unsafe.Pointer(cpath))
+	ciface := C.CString(interfaceName)
+	defer C.free(unsafe.Pointer(ciface))
+	cmethod := C.CString(methodName)
+	defer C.free(unsafe.Pointer(cmethod))
+	var err *C.GError = nil
+	c := C.g_dbus_connection_call_sync(v.Native(), cname,
+		(*C.gchar)(cpath), (*C.gchar)(ciface), (*C.gchar)(cmethod),
+		variantPtr(parameters), nil, C.G_DBUS_CALL_FLAGS_NONE, timeout,
+		cancellable, &err)
+	if c == nil {
+		return nil, goDBusError(ctx, err)
+	}
+	return goVariant(c), nil
+}
+
+// DBusSignalCallback is the type of functions called when a signal
+// subscribed to with SignalSubscribe() is received.
+type DBusSignalCallback func(conn *DBusConnection, senderName, objectPath, interfaceName, signalName string, parameters *glib.Variant)
+
+// SignalSubscribe() is a wrapper around
+// g_dbus_connection_signal_subscribe().  Empty sender, interfaceName,
+// member, objectPath and arg0 strings match any value.  f is called from
+// the main loop until the subscription is removed with
+// SignalUnsubscribe().
+func (v *DBusConnection) SignalSubscribe(sender, interfaceName, member, objectPath, arg0 string, flags DBusSignalFlags, f DBusSignalCallback) uint {
+	csender := cStringOrNil(sender)
+	defer C.free(unsafe.Pointer(csender))
+	ciface := cStringOrNil(interfaceName)
+	defer C.free(unsafe.Pointer(ciface))
+	cmember := cStringOrNil(member)
+	defer C.free(unsafe.Pointer(cmember))
+	cpath := cStringOrNil(objectPath)
+	defer C.free(unsafe.Pointer(cpath))
+	carg0 := cStringOrNil(arg0)
+	defer C.free(unsafe.Pointer(carg0))
+	closure := glib.ClosureNew(func(_ *glib.Object, senderName, objectPath, interfaceName, signalName string, parameters *glib.Variant) {
+		f(v, senderName, objectPath, interfaceName, signalName, parameters)
+	})
+	c := C._g_dbus_connection_signal_subscribe(v.Native(), csender, ciface,
+		cmember, cpath, carg0, C.GDBusSignalFlags(flags),
+		(*C.GClosure)(unsafe.Pointer(closure)))
+	return uint(c)
+}
+
+// SignalUnsubscribe() is a wrapper around
+// g_dbus_connection_signal_unsubscribe().
+func (v *DBusConnection) SignalUnsubscribe(subscriptionId uint) {
+	C.g_dbus_connection_signal_unsubscribe(v.Native(), C.guint(subscriptionId))
+}
+
+// EmitSignal() is a wrapper around g_dbus_connection_emit_signal().  An
+// empty destinationBusName broadcasts the signal.  parameters must be a
+// tuple, or nil if the signal has no arguments.
+func (v *DBusConnection) EmitSignal(destinationBusName, objectPath, interfaceName, signalName string, parameters *glib.Variant) error {
+	cdest := cStringOrNil(destinationBusName)
+	defer C.free(unsafe.Pointer(cdest))
+	cpath := C.CString(objectPath)
+	defer C.free(unsafe.Pointer(cpath))
+	ciface := C.CString(interfaceName)
+	defer C.free(unsafe.Pointer(ciface))
+	csignal := C.CString(signalName)
+	defer C.free(unsafe.Pointer(csignal))
+	var err *C.GError = nil
+	c := C.g_dbus_connection_emit_signal(v.Native(), cdest,
+		(*C.gchar)(cpath), (*C.gchar)(ciface), (*C.gchar)(csignal),
+		variantPtr(parameters), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// Flush() is a wrapper around g_dbus_connection_flush_sync().
+func (v *DBusConnection) Flush() error {
+	var err *C.GError = nil
+	c := C.g_dbus_connection_flush_sync(v.Native(), nil, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+/*
+ * D-Bus proxies
+ */
+
+// DBusProxy is a representation of GIO's GDBusProxy.
+type DBusProxy struct {
+	*glib.Object
+}
+
+func wrapDBusProxy(obj *glib.Object) *DBusProxy {
+	return &DBusProxy{obj}
+}
+
+// Native() returns a pointer to the underlying GDBusProxy.
+func (v *DBusProxy) Native() *C.GDBusProxy {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GDBusProxy)(v.Ptr())
+}
+
+// DBusProxyNew() is a wrapper around g_dbus_proxy_new_sync().
+// interfaceInfo may be nil.  Unless DBUS_PROXY_FLAGS_DO_NOT_LOAD_PROPERTIES
+// is given, the remote object's properties are loaded into the proxy's
+// cache before it is returned, so, like DBusConnection.Call(), this should
+// not be called from the main loop's thread if the object is served by
+// the same process.  Signals on the proxy are delivered to the main
+// context that is the thread default when the proxy is created.
+func DBusProxyNew(ctx context.Context, conn *DBusConnection, flags DBusProxyFlags, interfaceInfo *DBusInterfaceInfo, name, objectPath, interfaceName string) (*DBusProxy, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	cname := cStringOrNil(name)
+	defer C.free(unsafe.Pointer(cname))
+	cpath := C.CString(objectPath)
+	defer C.free(unsafe.Pointer(cpath))
+	ciface := C.CString(interfaceName)
+	defer C.free(unsafe.Pointer(ciface))
+	var err *C.GError = nil
+	c := C.g_dbus_proxy_new_sync(conn.Native(), C.GDBusProxyFlags(flags),
+		interfaceInfo.Native(), cname, (*C.gchar)(cpath),
+		(*C.gchar)(ciface), cancellable, &err)
+	if c == nil {
+		return nil, goDBusError(ctx, err)
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	p := wrapDBusProxy(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return p, nil
+}
+
+// DBusProxyNewForBus() is a wrapper around
+// g_dbus_proxy_new_for_bus_sync().  It is like DBusProxyNew(), but
+// connects to the bus given by typ.
+func DBusProxyNewForBus(ctx context.Context, typ BusType, flags DBusProxyFlags, interfaceInfo *DBusInterfaceInfo, name, objectPath, interfaceName string) (*DBusProxy, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cpath := C.CString(objectPath)
+	defer C.free(unsafe.Pointer(cpath))
+	ciface := C.CString(interfaceName)
+	defer C.free(unsafe.Pointer(ciface))
+	var err *C.GError = nil
+	c := C.g_dbus_proxy_new_for_bus_sync(C.GBusType(typ),
+		C.GDBusProxyFlags(flags), interfaceInfo.Native(),
+		(*C.gchar)(cname), (*C.gchar)(cpath), (*C.gchar)(ciface),
+		cancellable, &err)
+	if c == nil {
+		return nil, goDBusError(ctx, err)
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	p := wrapDBusProxy(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return p, nil
+}
+
+// GetConnection() is a wrapper around g_dbus_proxy_get_connection().
+func (v *DBusProxy) GetConnection() *DBusConnection {
+	c := C.g_dbus_proxy_get_connection(v.Native())
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	conn := wrapDBusConnection(obj)
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return conn
+}
+
+// GetName() is a wrapper around g_dbus_proxy_get_name().
+func (v *DBusProxy) GetName() string {
+	c := C.g_dbus_proxy_get_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetNameOwner() is a wrapper around g_dbus_proxy_get_name_owner().  An
+// error is returned if the name currently has no owner.
+func (v *DBusProxy) GetNameOwner() (string, error) {
+	c := C.g_dbus_proxy_get_name_owner(v.Native())
+	if c == nil {
+		return "", nilPtrErr
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), nil
+}
+
+// GetObjectPath() is a wrapper around g_dbus_proxy_get_object_path().
+func (v *DBusProxy) GetObjectPath() string {
+	c := C.g_dbus_proxy_get_object_path(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetInterfaceName() is a wrapper around g_dbus_proxy_get_interface_name().
+func (v *DBusProxy) GetInterfaceName() string {
+	c := C.g_dbus_proxy_get_interface_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetCachedProperty() is a wrapper around
+// g_dbus_proxy_get_cached_property().  nil is returned if the property is
+// not in the cache.
+func (v *DBusProxy) GetCachedProperty(propertyName string) *glib.Variant {
+	cstr := C.CString(propertyName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_dbus_proxy_get_cached_property(v.Native(), (*C.gchar)(cstr))
+	return goVariant(c)
+}
+
+// SetCachedProperty() is a wrapper around
+// g_dbus_proxy_set_cached_property().  A nil value removes the property
+// from the cache.  The remote object is not changed.
+func (v *DBusProxy) SetCachedProperty(propertyName string, value *glib.Variant) {
+	cstr := C.CString(propertyName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_dbus_proxy_set_cached_property(v.Native(), (*C.gchar)(cstr),
+		variantPtr(value))
+}
+
+// GetCachedPropertyNames() is a wrapper around
+// g_dbus_proxy_get_cached_property_names().
+func (v *DBusProxy) GetCachedPropertyNames() []string {
+	c := C.g_dbus_proxy_get_cached_property_names(v.Native())
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// Call() is a wrapper around g_dbus_proxy_call_sync().  It calls the
+// method methodName of the proxy's interface, and is otherwise like
+// DBusConnection.Call().
+func (v *DBusProxy) Call(ctx context.Context, methodName string, parameters *glib.Variant) (*glib.Variant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	timeout := dbusTimeout(ctx)
+	cancellable, release := cancellableFromContext(ctx)
+	defer release()
+	cstr := C.CString(methodName)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_dbus_proxy_call_sync(v.Native(), (*C.gchar)(cstr),
+		variantPtr(parameters), C.G_DBUS_CALL_FLAGS_NONE, timeout,
+		cancellable, &err)
+	if c == nil {
+		return nil, goDBusError(ctx, err)
+	}
+	return goVariant(c), nil
+}
+
+// OnPropertiesChanged() connects f to the proxy's "g-properties-changed"
+// signal, which is emitted after the property cache is updated.
+// changed maps the names of changed properties to their new values, and
+// invalidated lists properties whose values were invalidated without
+// being sent.
+func (v *DBusProxy) OnPropertiesChanged(f func(changed map[string]*glib.Variant, invalidated []string)) glib.SignalHandle {
+	return v.Connect("g-properties-changed", func(_ *glib.Object, changed *glib.Variant, invalidated []string) {
+		props := make(map[string]*glib.Variant)
+		for i := uint(0); i < changed.NChildren(); i++ {
+			entry := changed.ChildValue(i)
+			props[entry.ChildValue(0).String()] = entry.ChildValue(1).ChildValue(0)
+		}
+		f(props, invalidated)
+	})
+}
+
+// OnSignal() connects f to the proxy's "g-signal" signal, which is
+// emitted for each D-Bus signal received from the remote object.
+func (v *DBusProxy) OnSignal(f func(senderName, signalName string, parameters *glib.Variant)) glib.SignalHandle {
+	return v.Connect("g-signal", func(_ *glib.Object, senderName, signalName string, parameters *glib.Variant) {
+		f(senderName, signalName, parameters)
+	})
+}
+
+// OnNameOwnerChanged() connects f to notifications of the proxy's
+// "g-name-owner" property, which changes when the remote name gains or
+// loses an owner.
+func (v *DBusProxy) OnNameOwnerChanged(f func()) glib.SignalHandle {
+	return v.Connect("notify::g-name-owner", f)
+}
+
+/*
+ * D-Bus introspection data
+ */
+
+// DBusNodeInfo is a representation of GIO's GDBusNodeInfo.
+type DBusNodeInfo struct {
+	ptr *C.GDBusNodeInfo
+}
+
+// Native() returns a pointer to the underlying GDBusNodeInfo.
+func (v *DBusNodeInfo) Native() *C.GDBusNodeInfo {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// DBusNodeInfoNewForXML() is a wrapper around
+// g_dbus_node_info_new_for_xml().
+func DBusNodeInfoNewForXML(xmlData string) (*DBusNodeInfo, error) {
+	cstr := C.CString(xmlData)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_dbus_node_info_new_for_xml((*C.gchar)(cstr), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	info := &DBusNodeInfo{c}
+	runtime.SetFinalizer(info, (*DBusNodeInfo).unref)
+	return info, nil
+}
+
+func (v *DBusNodeInfo) unref() {
+	C.g_dbus_node_info_unref(v.ptr)
+}
+
+// LookupInterface() is a wrapper around
+// g_dbus_node_info_lookup_interface().
+func (v *DBusNodeInfo) LookupInterface(name string) (*DBusInterfaceInfo, error) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_dbus_node_info_lookup_interface(v.ptr, (*C.gchar)(cstr))
+	if c == nil {
+		return nil, errors.New("interface '" + name + "' not found")
+	}
+	return wrapDBusInterfaceInfo(c), nil
+}
+
+// Interfaces() returns the interfaces described by the node.
+func (v *DBusNodeInfo) Interfaces() []*DBusInterfaceInfo {
+	var ifaces []*DBusInterfaceInfo
+	if v.ptr.interfaces == nil {
+		return ifaces
+	}
+	for p := v.ptr.interfaces; *p != nil; p = (**C.GDBusInterfaceInfo)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + unsafe.Sizeof(*p))) {
+		ifaces = append(ifaces, wrapDBusInterfaceInfo(*p))
+	}
+	return ifaces
+}
+
+// DBusInterfaceInfo is a representation of GIO's GDBusInterfaceInfo.
+type DBusInterfaceInfo struct {
+	ptr *C.GDBusInterfaceInfo
+}
+
+func wrapDBusInterfaceInfo(c *C.GDBusInterfaceInfo) *DBusInterfaceInfo {
+	info := &DBusInterfaceInfo{C.g_dbus_interface_info_ref(c)}
+	runtime.SetFinalizer(info, (*DBusInterfaceInfo).unref)
+	return info
+}
+
+func (v *DBusInterfaceInfo) unref() {
+	C.g_dbus_interface_info_unref(v.ptr)
+}
+
+// Native() returns a pointer to the underlying GDBusInterfaceInfo.
+func (v *DBusInterfaceInfo) Native() *C.GDBusInterfaceInfo {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// Name() returns the name of the interface.
+func (v *DBusInterfaceInfo) Name() string {
+	return C.GoString((*C.char)(v.ptr.name))
+}
+
+/*
+ * D-Bus object registration
+ */
+
+// DBusMethodInvocation is a representation of GIO's
+// GDBusMethodInvocation.  Exactly one of its Return methods must be
+// called to reply to the method call, after which the invocation must
+// no longer be used.  The reply may be sent from any goroutine.
+type DBusMethodInvocation struct {
+	*glib.Object
+}
+
+// Native() returns a pointer to the underlying GDBusMethodInvocation.
+func (v *DBusMethodInvocation) Native() *C.GDBusMethodInvocation {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GDBusMethodInvocation)(v.Ptr())
+}
+
+// GetConnection() is a wrapper around
+// g_dbus_method_invocation_get_connection().
+func (v *DBusMethodInvocation) GetConnection() *DBusConnection {
+	c := C.g_dbus_method_invocation_get_connection(v.Native())
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	conn := wrapDBusConnection(obj)
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return conn
+}
+
+// GetSender() is a wrapper around g_dbus_method_invocation_get_sender().
+func (v *DBusMethodInvocation) GetSender() string {
+	c := C.g_dbus_method_invocation_get_sender(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetObjectPath() is a wrapper around
+// g_dbus_method_invocation_get_object_path().
+func (v *DBusMethodInvocation) GetObjectPath() string {
+	c := C.g_dbus_method_invocation_get_object_path(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetInterfaceName() is a wrapper around
+// g_dbus_method_invocation_get_interface_name().
+func (v *DBusMethodInvocation) GetInterfaceName() string {
+	c := C.g_dbus_method_invocation_get_interface_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetMethodName() is a wrapper around
+// g_dbus_method_invocation_get_method_name().
+func (v *DBusMethodInvocation) GetMethodName() string {
+	c := C.g_dbus_method_invocation_get_method_name(v.Native())
+	return C.GoString((*C.char)(c))
+}
+
+// GetParameters() is a wrapper around
+// g_dbus_method_invocation_get_parameters().  The parameters are returned
+// as a tuple.
+func (v *DBusMethodInvocation) GetParameters() *glib.Variant {
+	c := C.g_dbus_method_invocation_get_parameters(v.Native())
+	return glib.VariantFromPtr(unsafe.Pointer(c), glib.TRANSFER_NONE)
+}
+
+// ReturnValue() is a wrapper around
+// g_dbus_method_invocation_return_value().  parameters must be a tuple,
+// or nil if the method returns nothing.
+func (v *DBusMethodInvocation) ReturnValue(parameters *glib.Variant) {
+	C.g_dbus_method_invocation_return_value(v.Native(),
+		variantPtr(parameters))
+}
+
+// ReturnError() replies to the method call with err, using
+// g_dbus_method_invocation_return_dbus_error().  A *DBusError is returned
+// to the caller with its own name; any other error is returned as
+// org.freedesktop.DBus.Error.Failed.
+func (v *DBusMethodInvocation) ReturnError(err error) {
+	name, message := dbusErrorName(err)
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	C.g_dbus_method_invocation_return_dbus_error(v.Native(),
+		(*C.gchar)(cname), (*C.gchar)(cmsg))
+}
+
+// dbusErrorName() returns the D-Bus error name and message to report
+// for err.
+func dbusErrorName(err error) (name, message string) {
+	if dbusErr, ok := err.(*DBusError); ok {
+		return dbusErr.Name, dbusErr.Message
+	}
+	return "org.freedesktop.DBus.Error.Failed", err.Error()
+}
+
+// setDBusError() sets the GError pointed to by cerr from err.
+func setDBusError(cerr **C.GError, err error) {
+	name, message := dbusErrorName(err)
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	C._g_dbus_error_set(cerr, (*C.gchar)(cname), (*C.gchar)(cmsg))
+}
+
+// DBusInterfaceHandler is implemented by Go values serving a D-Bus
+// interface registered with RegisterObject().  Its methods are called
+// from the main loop.
+type DBusInterfaceHandler interface {
+	// MethodCall() is called for each method call on the interface,
+	// and must reply to it with one of the invocation's Return
+	// methods, either before returning or later.
+	MethodCall(invocation *DBusMethodInvocation)
+
+	// GetProperty() returns the current value of a property.
+	GetProperty(sender, objectPath, interfaceName, propertyName string) (*glib.Variant, error)
+
+	// SetProperty() sets the value of a writable property.
+	SetProperty(sender, objectPath, interfaceName, propertyName string, value *glib.Variant) error
+}
+
+// dbusObjects holds the handlers of registered objects, keyed by an id
+// passed as the user data of their registration.  Ids are never reused,
+// so a stale id cannot refer to another registration.
+var dbusObjects = struct {
+	sync.RWMutex
+	m    map[C.guint]DBusInterfaceHandler
+	next C.guint
+}{
+	m: make(map[C.guint]DBusInterfaceHandler),
+}
+
+func dbusObjectHandler(id C.guint) DBusInterfaceHandler {
+	dbusObjects.RLock()
+	defer dbusObjects.RUnlock()
+	return dbusObjects.m[id]
+}
+
+//export goDBusMethodCall
+func goDBusMethodCall(invocation *C.GDBusMethodInvocation, id C.guint) {
+	handler := dbusObjectHandler(id)
+	handler.MethodCall(&DBusMethodInvocation{glib.ObjectNew(unsafe.Pointer(invocation))})
+}
+
+//export goDBusGetProperty
+func goDBusGetProperty(sender, objectPath, interfaceName, propertyName *C.gchar, cerr **C.GError, id C.guint) *C.GVariant {
+	handler := dbusObjectHandler(id)
+	value, err := handler.GetProperty(C.GoString((*C.char)(sender)),
+		C.GoString((*C.char)(objectPath)),
+		C.GoString((*C.char)(interfaceName)),
+		C.GoString((*C.char)(propertyName)))
+	if err != nil {
+		setDBusError(cerr, err)
+		return nil
+	}
+	if value == nil {
+		setDBusError(cerr, errors.New("property has no value"))
+		return nil
+	}
+	return C.g_variant_ref(variantPtr(value))
+}
+
+//export goDBusSetProperty
+func goDBusSetProperty(sender, objectPath, interfaceName, propertyName *C.gchar, value *C.GVariant, cerr **C.GError, id C.guint) C.gboolean {
+	handler := dbusObjectHandler(id)
+	err := handler.SetProperty(C.GoString((*C.char)(sender)),
+		C.GoString((*C.char)(objectPath)),
+		C.GoString((*C.char)(interfaceName)),
+		C.GoString((*C.char)(propertyName)),
+		glib.VariantFromPtr(unsafe.Pointer(value), glib.TRANSFER_NONE))
+	if err != nil {
+		setDBusError(cerr, err)
+		return gbool(false)
+	}
+	return gbool(true)
+}
+
+//export goDBusObjectFree
+func goDBusObjectFree(id C.guint) {
+	releaseDBusObject(id)
+}
+
+// releaseDBusObject() removes the handler registered under id, if it has
+// not already been removed.
+func releaseDBusObject(id C.guint) {
+	dbusObjects.Lock()
+	delete(dbusObjects.m, id)
+	dbusObjects.Unlock()
+}
+
+// RegisterObject() is a wrapper around
+// g_dbus_connection_register_object().  Calls to the interface described
+// by interfaceInfo at objectPath are dispatched to handler until the
+// object is unregistered with UnregisterObject().
+func (v *DBusConnection) RegisterObject(objectPath string, interfaceInfo *DBusInterfaceInfo, handler DBusInterfaceHandler) (uint, error) {
+	cstr := C.CString(objectPath)
+	defer C.free(unsafe.Pointer(cstr))
+	dbusObjects.Lock()
+	dbusObjects.next++
+	id := dbusObjects.next
+	dbusObjects.m[id] = handler
+	dbusObjects.Unlock()
+	var err *C.GError = nil
+	c := C._g_dbus_connection_register_object(v.Native(), (*C.gchar)(cstr),
+		interfaceInfo.Native(), id, &err)
+	if c == 0 {
+		// Depending on the GLib version, the user data may or may not
+		// have been released already.
+		releaseDBusObject(id)
+		return 0, goError(err)
+	}
+	return uint(c), nil
+}
+
+// UnregisterObject() is a wrapper around
+// g_dbus_connection_unregister_object().
+func (v *DBusConnection) UnregisterObject(registrationId uint) bool {
+	c := C.g_dbus_connection_unregister_object(v.Native(),
+		C.guint(registrationId))
+	return gobool(c)
+}
+
+/*
+ * D-Bus object export
+ */
+
+var (
+	errorType       = reflect.TypeOf((*error)(nil)).Elem()
+	variantPtrType  = reflect.TypeOf((*glib.Variant)(nil))
+	objectPathType  = reflect.TypeOf(glib.ObjectPath(""))
+	signatureType   = reflect.TypeOf(glib.Signature(""))
+	stringSliceType = reflect.TypeOf([]string(nil))
+	dbusBasicSigs   = map[reflect.Kind]string{
+		reflect.Bool:    "b",
+		reflect.Uint8:   "y",
+		reflect.Int16:   "n",
+		reflect.Uint16:  "q",
+		reflect.Int32:   "i",
+		reflect.Uint32:  "u",
+		reflect.Int64:   "x",
+		reflect.Uint64:  "t",
+		reflect.Float64: "d",
+		reflect.String:  "s",
+	}
+)
+
+// dbusSignature() returns the D-Bus type signature of Go values of type
+// t, or an empty string if t has no D-Bus equivalent.
+func dbusSignature(t reflect.Type) string {
+	switch t {
+	case variantPtrType:
+		return "v"
+	case objectPathType:
+		return "o"
+	case signatureType:
+		return "g"
+	}
+	if t.Kind() == reflect.Slice && t.ConvertibleTo(stringSliceType) {
+		return "as"
+	}
+	return dbusBasicSigs[t.Kind()]
+}
+
+// variantToGo() converts v to a Go value of type t, which must have a
+// D-Bus signature matching the type of v.
+func variantToGo(v *glib.Variant, t reflect.Type) reflect.Value {
+	var val interface{}
+	switch t {
+	case variantPtrType:
+		return reflect.ValueOf(v.ChildValue(0))
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		return reflect.ValueOf(v.Strv()).Convert(t)
+	case reflect.Bool:
+		val = v.Boolean()
+	case reflect.Uint8:
+		val = v.Byte()
+	case reflect.Int16:
+		val = v.Int16()
+	case reflect.Uint16:
+		val = v.Uint16()
+	case reflect.Int32:
+		val = v.Int32()
+	case reflect.Uint32:
+		val = v.Uint32()
+	case reflect.Int64:
+		val = v.Int64()
+	case reflect.Uint64:
+		val = v.Uint64()
+	case reflect.Float64:
+		val = v.Double()
+	case reflect.String:
+		val = v.String()
+	}
+	return reflect.ValueOf(val).Convert(t)
+}
+
+// goToVariant() converts a Go value with a D-Bus signature to a Variant.
+func goToVariant(val reflect.Value) (*glib.Variant, error) {
+	switch val.Type() {
+	case variantPtrType:
+		if val.IsNil() {
+			return nil, errors.New("nil *glib.Variant result")
+		}
+		return glib.VariantNew(val.Interface())
+	case objectPathType, signatureType:
+		return glib.VariantNew(val.Interface())
+	}
+	var t reflect.Type
+	switch val.Kind() {
+	case reflect.Slice:
+		t = stringSliceType
+	case reflect.Bool:
+		t = reflect.TypeOf(false)
+	case reflect.Uint8:
+		t = reflect.TypeOf(byte(0))
+	case reflect.Int16:
+		t = reflect.TypeOf(int16(0))
+	case reflect.Uint16:
+		t = reflect.TypeOf(uint16(0))
+	case reflect.Int32:
+		t = reflect.TypeOf(int32(0))
+	case reflect.Uint32:
+		t = reflect.TypeOf(uint32(0))
+	case reflect.Int64:
+		t = reflect.TypeOf(int64(0))
+	case reflect.Uint64:
+		t = reflect.TypeOf(uint64(0))
+	case reflect.Float64:
+		t = reflect.TypeOf(float64(0))
+	case reflect.String:
+		t = reflect.TypeOf("")
+	default:
+		return nil, errors.New("unsupported type " + val.Type().String())
+	}
+	return glib.VariantNew(val.Convert(t).Interface())
+}
+
+// exportedMethod holds a method of an exported Go value and the number
+// of D-Bus out arguments it returns.
+type exportedMethod struct {
+	fn      reflect.Value
+	nOut    int
+	withErr bool
+}
+
+// exportedObject is the DBusInterfaceHandler used by Export().
+type exportedObject struct {
+	methods map[string]exportedMethod
+}
+
+func (o *exportedObject) MethodCall(invocation *DBusMethodInvocation) {
+	m, ok := o.methods[invocation.GetMethodName()]
+	if !ok {
+		invocation.ReturnError(&DBusError{
+			Name:    "org.freedesktop.DBus.Error.UnknownMethod",
+			Message: "no such method " + invocation.GetMethodName(),
+		})
+		return
+	}
+	params := invocation.GetParameters()
+	t := m.fn.Type()
+	args := make([]reflect.Value, t.NumIn())
+	for i := range args {
+		args[i] = variantToGo(params.ChildValue(uint(i)), t.In(i))
+	}
+	results := m.fn.Call(args)
+	if m.withErr {
+		if err, _ := results[len(results)-1].Interface().(error); err != nil {
+			invocation.ReturnError(err)
+			return
+		}
+	}
+	out := make([]*glib.Variant, m.nOut)
+	for i := range out {
+		v, err := goToVariant(results[i])
+		if err != nil {
+			invocation.ReturnError(err)
+			return
+		}
+		out[i] = v
+	}
+	if len(out) == 0 {
+		invocation.ReturnValue(nil)
+		return
+	}
+	invocation.ReturnValue(glib.VariantTuple(out...))
+}
+
+func (o *exportedObject) GetProperty(sender, objectPath, interfaceName, propertyName string) (*glib.Variant, error) {
+	return nil, errors.New("no such property " + propertyName)
+}
+
+func (o *exportedObject) SetProperty(sender, objectPath, interfaceName, propertyName string, value *glib.Variant) error {
+	return errors.New("no such property " + propertyName)
+}
+
+// Export() registers obj at objectPath, serving each of its exported
+// methods as a method of the D-Bus interface interfaceName.  Arguments
+// and results are marshaled between Go and D-Bus types as follows:
+//
+//	bool            b
+//	byte            y
+//	int16, uint16   n, q
+//	int32, uint32   i, u
+//	int64, uint64   x, t
+//	float64         d
+//	string          s
+//	glib.ObjectPath o
+//	glib.Signature  g
+//	[]string        as
+//	*glib.Variant   v
+//
+// Other types whose underlying type is one of the Go types listed, such
+// as a named []string type, are marshaled in the same way.
+// If a method's last result is an error, it is not sent to the caller;
+// when non-nil, it is returned as a D-Bus error as described for
+// DBusMethodInvocation.ReturnError().  Methods with other argument or
+// result types are not exported.  The registration ID is returned for
+// use with UnregisterObject().
+func (v *DBusConnection) Export(obj interface{}, objectPath, interfaceName string) (uint, error) {
+	cstr := C.CString(interfaceName)
+	defer C.free(unsafe.Pointer(cstr))
+	if !gobool(C.g_dbus_is_interface_name((*C.gchar)(cstr))) {
+		return 0, errors.New("invalid interface name: " + interfaceName)
+	}
+
+	handler := &exportedObject{make(map[string]exportedMethod)}
+	val := reflect.ValueOf(obj)
+	xml := []string{`<node><interface name="` + interfaceName + `">`}
+methods:
+	for i := 0; i < val.NumMethod(); i++ {
+		name := val.Type().Method(i).Name
+		fn := val.Method(i)
+		t := fn.Type()
+		m := exportedMethod{fn: fn, nOut: t.NumOut()}
+		if m.nOut > 0 && t.Out(m.nOut-1) == errorType {
+			m.nOut--
+			m.withErr = true
+		}
+		args := []string{}
+		for j := 0; j < t.NumIn(); j++ {
+			sig := dbusSignature(t.In(j))
+			if sig == "" {
+				continue methods
+			}
+			args = append(args, `<arg type="`+sig+`" direction="in"/>`)
+		}
+		for j := 0; j < m.nOut; j++ {
+			sig := dbusSignature(t.Out(j))
+			if sig == "" {
+				continue methods
+			}
+			args = append(args, `<arg type="`+sig+`" direction="out"/>`)
+		}
+		handler.methods[name] = m
+		xml = append(xml, `<method name="`+name+`">`+strings.Join(args, "")+`</method>`)
+	}
+	xml = append(xml, `</interface></node>`)
+
+	node, err := DBusNodeInfoNewForXML(strings.Join(xml, ""))
+	if err != nil {
+		return 0, err
+	}
+	iface, err := node.LookupInterface(interfaceName)
+	if err != nil {
+		return 0, err
+	}
+	return v.RegisterObject(objectPath, iface, handler)
+}
+
+/*
+ * Settings
+ */
+
+type SettingsBindFlags int
+
+const (
+	SETTINGS_BIND_DEFAULT        SettingsBindFlags = C.G_SETTINGS_BIND_DEFAULT
+	SETTINGS_BIND_GET                              = C.G_SETTINGS_BIND_GET
+	SETTINGS_BIND_SET                              = C.G_SETTINGS_BIND_SET
+	SETTINGS_BIND_NO_SENSITIVITY                   = C.G_SETTINGS_BIND_NO_SENSITIVITY
+	SETTINGS_BIND_GET_NO_CHANGES                   = C.G_SETTINGS_BIND_GET_NO_CHANGES
+	SETTINGS_BIND_INVERT_BOOLEAN                   = C.G_SETTINGS_BIND_INVERT_BOOLEAN
+)
+
+// Settings is a representation of GIO's GSettings.
+type Settings struct {
+	*glib.Object
+}
+
+func wrapSettings(obj *glib.Object) *Settings {
+	return &Settings{obj}
+}
+
+// Native() returns a pointer to the underlying GSettings.
+func (v *Settings) Native() *C.GSettings {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSettings)(v.Ptr())
+}
+
+// SettingsNew() is a wrapper around g_settings_new().  An error is
+// returned if the schema is not installed or is relocatable, instead of
+// aborting the program as g_settings_new() does.
+func SettingsNew(schemaId string) (*Settings, error) {
+	source, err := SettingsSchemaSourceGetDefault()
+	if err != nil {
+		return nil, errors.New("schema '" + schemaId + "' is not installed")
+	}
+	schema, err := source.Lookup(schemaId, true)
+	if err != nil {
+		return nil, err
+	}
+	if schema.GetPath() == "" {
+		return nil, errors.New("schema '" + schemaId + "' is relocatable")
+	}
+	cstr := C.CString(schemaId)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_new((*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	settings := wrapSettings(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return settings, nil
+}
+
+// SettingsNewFull() is a wrapper around g_settings_new_full().  backend
+// may be nil to use the default backend, and path must be empty unless
+// the schema is relocatable, in which case it is required.
+func SettingsNewFull(schema *SettingsSchema, backend *SettingsBackend, path string) (*Settings, error) {
+	hasPath := schema.GetPath() != ""
+	if hasPath && path != "" {
+		return nil, errors.New("path given for non-relocatable schema")
+	}
+	if !hasPath && path == "" {
+		return nil, errors.New("path required for relocatable schema")
+	}
+	cpath := cStringOrNil(path)
+	defer C.free(unsafe.Pointer(cpath))
+	c := C.g_settings_new_full(schema.Native(), backend.Native(), cpath)
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	settings := wrapSettings(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return settings, nil
+}
+
+// GetValue() is a wrapper around g_settings_get_value().
+func (v *Settings) GetValue(key string) *glib.Variant {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_value(v.Native(), (*C.gchar)(cstr))
+	return goVariant(c)
+}
+
+// SetValue() is a wrapper around g_settings_set_value().  false is
+// returned if the key is not writable.
+func (v *Settings) SetValue(key string, value *glib.Variant) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_set_value(v.Native(), (*C.gchar)(cstr),
+		variantPtr(value))
+	return gobool(c)
+}
+
+// GetBoolean() is a wrapper around g_settings_get_boolean().
+func (v *Settings) GetBoolean(key string) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_boolean(v.Native(), (*C.gchar)(cstr))
+	return gobool(c)
+}
+
+// SetBoolean() is a wrapper around g_settings_set_boolean().  false is
+// returned if the key is not writable.
+func (v *Settings) SetBoolean(key string, value bool) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_set_boolean(v.Native(), (*C.gchar)(cstr), gbool(value))
+	return gobool(c)
+}
+
+// GetInt() is a wrapper around g_settings_get_int().
+func (v *Settings) GetInt(key string) int {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_int(v.Native(), (*C.gchar)(cstr))
+	return int(c)
+}
+
+// SetInt() is a wrapper around g_settings_set_int().  false is returned
+// if the key is not writable.
+func (v *Settings) SetInt(key string, value int) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_set_int(v.Native(), (*C.gchar)(cstr), C.gint(value))
+	return gobool(c)
+}
+
+// GetUint() is a wrapper around g_settings_get_uint().
+func (v *Settings) GetUint(key string) uint {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_uint(v.Native(), (*C.gchar)(cstr))
+	return uint(c)
+}
+
+// SetUint() is a wrapper around g_settings_set_uint().  false is returned
+// if the key is not writable.
+func (v *Settings) SetUint(key string, value uint) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_set_uint(v.Native(), (*C.gchar)(cstr), C.guint(value))
+	return gobool(c)
+}
+
+// GetDouble() is a wrapper around g_settings_get_double().
+func (v *Settings) GetDouble(key string) float64 {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_double(v.Native(), (*C.gchar)(cstr))
+	return float64(c)
+}
+
+// SetDouble() is a wrapper around g_settings_set_double().  false is
+// returned if the key is not writable.
+func (v *Settings) SetDouble(key string, value float64) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_set_double(v.Native(), (*C.gchar)(cstr),
+		C.gdouble(value))
+	return gobool(c)
+}
+
+// GetString() is a wrapper around g_settings_get_string().
+func (v *Settings) GetString(key string) string {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_string(v.Native(), (*C.gchar)(cstr))
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// SetString() is a wrapper around g_settings_set_string().  false is
+// returned if the key is not writable.
+func (v *Settings) SetString(key, value string) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	c := C.g_settings_set_string(v.Native(), (*C.gchar)(cstr),
+		(*C.gchar)(cvalue))
+	return gobool(c)
+}
+
+// GetStrv() is a wrapper around g_settings_get_strv().
+func (v *Settings) GetStrv(key string) []string {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_strv(v.Native(), (*C.gchar)(cstr))
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// SetStrv() is a wrapper around g_settings_set_strv().  false is returned
+// if the key is not writable.
+func (v *Settings) SetStrv(key string, value []string) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	cvalue := make([]*C.gchar, len(value)+1)
+	for i, str := range value {
+		cvalue[i] = (*C.gchar)(C.CString(str))
+		defer C.free(unsafe.Pointer(cvalue[i]))
+	}
+	c := C.g_settings_set_strv(v.Native(), (*C.gchar)(cstr),
+		(**C.gchar)(unsafe.Pointer(&cvalue[0])))
+	return gobool(c)
+}
+
+// GetEnum() is a wrapper around g_settings_get_enum().
+func (v *Settings) GetEnum(key string) int {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_enum(v.Native(), (*C.gchar)(cstr))
+	return int(c)
+}
+
+// SetEnum() is a wrapper around g_settings_set_enum().  false is returned
+// if the key is not writable or value is not valid for it.
+func (v *Settings) SetEnum(key string, value int) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_set_enum(v.Native(), (*C.gchar)(cstr), C.gint(value))
+	return gobool(c)
+}
+
+// Reset() is a wrapper around g_settings_reset().
+func (v *Settings) Reset(key string) {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_settings_reset(v.Native(), (*C.gchar)(cstr))
+}
+
+// IsWritable() is a wrapper around g_settings_is_writable().
+func (v *Settings) IsWritable(key string) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_is_writable(v.Native(), (*C.gchar)(cstr))
+	return gobool(c)
+}
+
+// ListKeys() is a wrapper around g_settings_list_keys().
+func (v *Settings) ListKeys() []string {
+	c := C.g_settings_list_keys(v.Native())
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// ListChildren() is a wrapper around g_settings_list_children().
+func (v *Settings) ListChildren() []string {
+	c := C.g_settings_list_children(v.Native())
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// GetChild() is a wrapper around g_settings_get_child().
+func (v *Settings) GetChild(name string) (*Settings, error) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_get_child(v.Native(), (*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	settings := wrapSettings(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return settings, nil
+}
+
+// OnChanged() connects f to the settings' "changed" signal.  If key is
+// not empty, f is only called when that key changes.
+func (v *Settings) OnChanged(key string, f func(key string)) glib.SignalHandle {
+	signal := "changed"
+	if key != "" {
+		signal += "::" + key
+	}
+	return v.Connect(signal, func(_ *glib.Object, key string) {
+		f(key)
+	})
+}
+
+// Delay() is a wrapper around g_settings_delay().
+func (v *Settings) Delay() {
+	C.g_settings_delay(v.Native())
+}
+
+// Apply() is a wrapper around g_settings_apply().
+func (v *Settings) Apply() {
+	C.g_settings_apply(v.Native())
+}
+
+// Revert() is a wrapper around g_settings_revert().
+func (v *Settings) Revert() {
+	C.g_settings_revert(v.Native())
+}
+
+// GetHasUnapplied() is a wrapper around g_settings_get_has_unapplied().
+func (v *Settings) GetHasUnapplied() bool {
+	c := C.g_settings_get_has_unapplied(v.Native())
+	return gobool(c)
+}
+
+// Bind() is a wrapper around g_settings_bind().  It keeps the property
+// of obj, such as a gtk.Switch's "active" property, in sync with key.
+func (v *Settings) Bind(key string, obj glib.IObject, property string, flags SettingsBindFlags) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cprop := C.CString(property)
+	defer C.free(unsafe.Pointer(cprop))
+	C.g_settings_bind(v.Native(), (*C.gchar)(ckey),
+		C.gpointer(obj.ToObject().Ptr()), (*C.gchar)(cprop),
+		C.GSettingsBindFlags(flags))
+}
+
+// BindWritable() is a wrapper around g_settings_bind_writable().  It sets
+// the property of obj, such as a widget's "sensitive" property, to
+// whether key is writable.
+func (v *Settings) BindWritable(key string, obj glib.IObject, property string, inverted bool) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cprop := C.CString(property)
+	defer C.free(unsafe.Pointer(cprop))
+	C.g_settings_bind_writable(v.Native(), (*C.gchar)(ckey),
+		C.gpointer(obj.ToObject().Ptr()), (*C.gchar)(cprop),
+		gbool(inverted))
+}
+
+// SettingsUnbind() is a wrapper around g_settings_unbind().  It removes
+// the binding of a property created by Bind() or BindWritable().
+func SettingsUnbind(obj glib.IObject, property string) {
+	cstr := C.CString(property)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_settings_unbind(C.gpointer(obj.ToObject().Ptr()), (*C.gchar)(cstr))
+}
+
+// SettingsSync() is a wrapper around g_settings_sync().
+func SettingsSync() {
+	C.g_settings_sync()
+}
+
+/*
+ * SettingsBackend
+ */
+
+// SettingsBackend is a representation of GIO's GSettingsBackend.
+type SettingsBackend struct {
+	*glib.Object
+}
+
+func wrapSettingsBackend(obj *glib.Object) *SettingsBackend {
+	return &SettingsBackend{obj}
+}
+
+// Native() returns a pointer to the underlying GSettingsBackend.
+func (v *SettingsBackend) Native() *C.GSettingsBackend {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GSettingsBackend)(v.Ptr())
+}
+
+func settingsBackendFromNative(c *C.GSettingsBackend) (*SettingsBackend, error) {
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	b := wrapSettingsBackend(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return b, nil
+}
+
+// MemorySettingsBackendNew() is a wrapper around
+// g_memory_settings_backend_new().  Settings stored in the backend are
+// lost when it is freed, which makes it suitable for tests.
+func MemorySettingsBackendNew() (*SettingsBackend, error) {
+	return settingsBackendFromNative(C.g_memory_settings_backend_new())
+}
+
+// NullSettingsBackendNew() is a wrapper around
+// g_null_settings_backend_new().
+func NullSettingsBackendNew() (*SettingsBackend, error) {
+	return settingsBackendFromNative(C.g_null_settings_backend_new())
+}
+
+// KeyfileSettingsBackendNew() is a wrapper around
+// g_keyfile_settings_backend_new().  Settings below the path rootPath,
+// such as "/org/example/app/", are stored in filename.  rootGroup may be
+// empty.
+func KeyfileSettingsBackendNew(filename, rootPath, rootGroup string) (*SettingsBackend, error) {
+	cfile := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfile))
+	cpath := C.CString(rootPath)
+	defer C.free(unsafe.Pointer(cpath))
+	cgroup := cStringOrNil(rootGroup)
+	defer C.free(unsafe.Pointer(cgroup))
+	return settingsBackendFromNative(C.g_keyfile_settings_backend_new(
+		(*C.gchar)(cfile), (*C.gchar)(cpath), cgroup))
+}
+
+/*
+ * SettingsSchemaSource
+ */
+
+// SettingsSchemaSource is a representation of GIO's
+// GSettingsSchemaSource.
+type SettingsSchemaSource struct {
+	ptr *C.GSettingsSchemaSource
+}
+
+func wrapSettingsSchemaSource(c *C.GSettingsSchemaSource) *SettingsSchemaSource {
+	source := &SettingsSchemaSource{c}
+	runtime.SetFinalizer(source, (*SettingsSchemaSource).unref)
+	return source
+}
+
+func (v *SettingsSchemaSource) unref() {
+	C.g_settings_schema_source_unref(v.ptr)
+}
+
+// Native() returns a pointer to the underlying GSettingsSchemaSource.
+func (v *SettingsSchemaSource) Native() *C.GSettingsSchemaSource {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// SettingsSchemaSourceGetDefault() is a wrapper around
+// g_settings_schema_source_get_default().  An error is returned if no
+// schemas are installed.
+func SettingsSchemaSourceGetDefault() (*SettingsSchemaSource, error) {
+	c := C.g_settings_schema_source_get_default()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	return wrapSettingsSchemaSource(C.g_settings_schema_source_ref(c)), nil
+}
+
+// SettingsSchemaSourceNewFromDirectory() is a wrapper around
+// g_settings_schema_source_new_from_directory().  directory must contain
+// a gschemas.compiled file, as created by glib-compile-schemas.  parent
+// may be nil, or the source returned by SettingsSchemaSourceGetDefault()
+// to also find installed schemas.
+func SettingsSchemaSourceNewFromDirectory(directory string, parent *SettingsSchemaSource, trusted bool) (*SettingsSchemaSource, error) {
+	cstr := C.CString(directory)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_settings_schema_source_new_from_directory((*C.gchar)(cstr),
+		parent.Native(), gbool(trusted), &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	return wrapSettingsSchemaSource(c), nil
+}
+
+// Lookup() is a wrapper around g_settings_schema_source_lookup().  If
+// recursive is true, parent sources are also searched.
+func (v *SettingsSchemaSource) Lookup(schemaId string, recursive bool) (*SettingsSchema, error) {
+	cstr := C.CString(schemaId)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_schema_source_lookup(v.ptr, (*C.gchar)(cstr),
+		gbool(recursive))
+	if c == nil {
+		return nil, errors.New("schema '" + schemaId + "' not found")
+	}
+	return wrapSettingsSchema(c), nil
+}
+
+// ListSchemas() is a wrapper around
+// g_settings_schema_source_list_schemas().
+func (v *SettingsSchemaSource) ListSchemas(recursive bool) (nonRelocatable, relocatable []string) {
+	var cnon, creloc **C.gchar
+	C.g_settings_schema_source_list_schemas(v.ptr, gbool(recursive),
+		&cnon, &creloc)
+	defer C.g_strfreev(cnon)
+	defer C.g_strfreev(creloc)
+	return goStrv(cnon), goStrv(creloc)
+}
+
+/*
+ * SettingsSchema
+ */
+
+// SettingsSchema is a representation of GIO's GSettingsSchema.
+type SettingsSchema struct {
+	ptr *C.GSettingsSchema
+}
+
+func wrapSettingsSchema(c *C.GSettingsSchema) *SettingsSchema {
+	schema := &SettingsSchema{c}
+	runtime.SetFinalizer(schema, (*SettingsSchema).unref)
+	return schema
+}
+
+func (v *SettingsSchema) unref() {
+	C.g_settings_schema_unref(v.ptr)
+}
+
+// Native() returns a pointer to the underlying GSettingsSchema.
+func (v *SettingsSchema) Native() *C.GSettingsSchema {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// GetId() is a wrapper around g_settings_schema_get_id().
+func (v *SettingsSchema) GetId() string {
+	c := C.g_settings_schema_get_id(v.ptr)
+	return C.GoString((*C.char)(c))
+}
+
+// GetPath() is a wrapper around g_settings_schema_get_path().  The path
+// is empty for relocatable schemas.
+func (v *SettingsSchema) GetPath() string {
+	c := C.g_settings_schema_get_path(v.ptr)
+	return C.GoString((*C.char)(c))
+}
+
+// HasKey() is a wrapper around g_settings_schema_has_key().
+func (v *SettingsSchema) HasKey(name string) bool {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_schema_has_key(v.ptr, (*C.gchar)(cstr))
+	return gobool(c)
+}
+
+// GetKey() is a wrapper around g_settings_schema_get_key().
+func (v *SettingsSchema) GetKey(name string) (*SettingsSchemaKey, error) {
+	if !v.HasKey(name) {
+		return nil, errors.New("key '" + name + "' not found")
+	}
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_settings_schema_get_key(v.ptr, (*C.gchar)(cstr))
+	key := &SettingsSchemaKey{c}
+	runtime.SetFinalizer(key, (*SettingsSchemaKey).unref)
+	return key, nil
+}
+
+// ListKeys() is a wrapper around g_settings_schema_list_keys().
+func (v *SettingsSchema) ListKeys() []string {
+	c := C.g_settings_schema_list_keys(v.ptr)
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// ListChildren() is a wrapper around g_settings_schema_list_children().
+func (v *SettingsSchema) ListChildren() []string {
+	c := C.g_settings_schema_list_children(v.ptr)
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+/*
+ * SettingsSchemaKey
+ */
+
+// SettingsSchemaKey is a representation of GIO's GSettingsSchemaKey.
+type SettingsSchemaKey struct {
+	ptr *C.GSettingsSchemaKey
+}
+
+func (v *SettingsSchemaKey) unref() {
+	C.g_settings_schema_key_unref(v.ptr)
+}
+
+// Native() returns a pointer to the underlying GSettingsSchemaKey.
+func (v *SettingsSchemaKey) Native() *C.GSettingsSchemaKey {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// GetName() is a wrapper around g_settings_schema_key_get_name().
+func (v *SettingsSchemaKey) GetName() string {
+	c := C.g_settings_schema_key_get_name(v.ptr)
+	return C.GoString((*C.char)(c))
+}
+
+// GetSummary() is a wrapper around g_settings_schema_key_get_summary().
+// An empty string is returned if the key has no summary.
+func (v *SettingsSchemaKey) GetSummary() string {
+	c := C.g_settings_schema_key_get_summary(v.ptr)
+	return C.GoString((*C.char)(c))
+}
+
+// GetDescription() is a wrapper around
+// g_settings_schema_key_get_description().  An empty string is returned
+// if the key has no description.
+func (v *SettingsSchemaKey) GetDescription() string {
+	c := C.g_settings_schema_key_get_description(v.ptr)
+	return C.GoString((*C.char)(c))
+}
+
+// GetValueType() is a wrapper around
+// g_settings_schema_key_get_value_type().  The type is returned as a
+// variant type string, such as "s".
+func (v *SettingsSchemaKey) GetValueType() string {
+	c := C.g_settings_schema_key_get_value_type(v.ptr)
+	return variantTypeString(c)
+}
+
+// GetDefaultValue() is a wrapper around
+// g_settings_schema_key_get_default_value().
+func (v *SettingsSchemaKey) GetDefaultValue() *glib.Variant {
+	c := C.g_settings_schema_key_get_default_value(v.ptr)
+	return goVariant(c)
+}
+
+// GetRange() is a wrapper around g_settings_schema_key_get_range().  The
+// range is returned as a tuple of a string, which is "type", "enum",
+// "flags" or "range", and a variant describing the permitted values.
+func (v *SettingsSchemaKey) GetRange() *glib.Variant {
+	c := C.g_settings_schema_key_get_range(v.ptr)
+	return goVariant(c)
+}
+
+// RangeCheck() is a wrapper around g_settings_schema_key_range_check().
+func (v *SettingsSchemaKey) RangeCheck(value *glib.Variant) bool {
+	c := C.g_settings_schema_key_range_check(v.ptr, variantPtr(value))
+	return gobool(c)
 }
@@ -1 +1,315 @@
 #include <stdlib.h>
+
+#define G_SETTINGS_ENABLE_BACKEND
+#include <gio/gsettingsbackend.h>
+
+static gchar *
+error_get_message(GError *error)
+{
+	return error->message;
+}
+
+/* Wrappers to avoid variable arg lists */
+static void
+_g_application_command_line_print(GApplicationCommandLine *cmdline,
+    gchar *msg)
+{
+	g_application_command_line_print(cmdline, "%s", msg);
+}
+
+static void
+_g_application_command_line_printerr(GApplicationCommandLine *cmdline,
+    gchar *msg)
+{
+	g_application_command_line_printerr(cmdline, "%s", msg);
+}
+
+/*
+ * D-Bus
+ */
+
+static void
+_g_dbus_signal_callback(GDBusConnection *connection, const gchar *sender_name,
+    const gchar *object_path, const gchar *interface_name,
+    const gchar *signal_name, GVariant *parameters, gpointer user_data)
+{
+	GValue values[6] = { G_VALUE_INIT, G_VALUE_INIT, G_VALUE_INIT,
+	    G_VALUE_INIT, G_VALUE_INIT, G_VALUE_INIT };
+	int i;
+
+	g_value_init(&values[0], G_TYPE_OBJECT);
+	g_value_set_object(&values[0], connection);
+	g_value_init(&values[1], G_TYPE_STRING);
+	g_value_set_string(&values[1], sender_name);
+	g_value_init(&values[2], G_TYPE_STRING);
+	g_value_set_string(&values[2], object_path);
+	g_value_init(&values[3], G_TYPE_STRING);
+	g_value_set_string(&values[3], interface_name);
+	g_value_init(&values[4], G_TYPE_STRING);
+	g_value_set_string(&values[4], signal_name);
+	g_value_init(&values[5], G_TYPE_VARIANT);
+	g_value_set_variant(&values[5], parameters);
+	g_closure_invoke((GClosure *)user_data, NULL, 6, values, NULL);
+	for (i = 0; i < 6; i++)
+		g_value_unset(&values[i]);
+}
+
+static guint
+_g_dbus_connection_signal_subscribe(GDBusConnection *connection,
+    gchar *sender, gchar *interface_name, gchar *member, gchar *object_path,
+    gchar *arg0, GDBusSignalFlags flags, GClosure *closure)
+{
+	g_closure_ref(closure);
+	g_closure_sink(closure);
+	return g_dbus_connection_signal_subscribe(connection, sender,
+	    interface_name, member, object_path, arg0, flags,
+	    _g_dbus_signal_callback, closure,
+	    (GDestroyNotify)g_closure_unref);
+}
+
+static void
+_g_dbus_error_set(GError **error, gchar *name, gchar *message)
+{
+	g_dbus_error_set_dbus_error(error, name, message, NULL);
+}
+
+extern void goDBusMethodCall(GDBusMethodInvocation *invocation, guint id);
+extern GVariant *goDBusGetProperty(gchar *sender, gchar *object_path,
+    gchar *interface_name, gchar *property_name, GError **error, guint id);
+extern gboolean goDBusSetProperty(gchar *sender, gchar *object_path,
+    gchar *interface_name, gchar *property_name, GVariant *value,
+    GError **error, guint id);
+extern void goDBusObjectFree(guint id);
+
+static void
+_g_dbus_method_call(GDBusConnection *connection, const gchar *sender,
+    const gchar *object_path, const gchar *interface_name,
+    const gchar *method_name, GVariant *parameters,
+    GDBusMethodInvocation *invocation, gpointer user_data)
+{
+	goDBusMethodCall(invocation, GPOINTER_TO_UINT(user_data));
+}
+
+static GVariant *
+_g_dbus_get_property(GDBusConnection *connection, const gchar *sender,
+    const gchar *object_path, const gchar *interface_name,
+    const gchar *property_name, GError **error, gpointer user_data)
+{
+	return goDBusGetProperty((gchar *)sender, (gchar *)object_path,
+	    (gchar *)interface_name, (gchar *)property_name, error,
+	    GPOINTER_TO_UINT(user_data));
+}
+
+static gboolean
+_g_dbus_set_property(GDBusConnection *connection, const gchar *sender,
+    const gchar *object_path, const gchar *interface_name,
+    const gchar *property_name, GVariant *value, GError **error,
+    gpointer user_data)
+{
+	return goDBusSetProperty((gchar *)sender, (gchar *)object_path,
+	    (gchar *)interface_name, (gchar *)property_name, value, error,
+	    GPOINTER_TO_UINT(user_data));
+}
+
+static const GDBusInterfaceVTable _g_dbus_interface_vtable = {
+	_g_dbus_method_call,
+	_g_dbus_get_property,
+	_g_dbus_set_property,
+};
+
+static void
+_g_dbus_object_free(gpointer user_data)
+{
+	goDBusObjectFree(GPOINTER_TO_UINT(user_data));
+}
+
+static guint
+_g_dbus_connection_register_object(GDBusConnection *connection,
+    gchar *object_path, GDBusInterfaceInfo *interface_info, guint id,
+    GError **error)
+{
+	return g_dbus_connection_register_object(connection, object_path,
+	    interface_info, &_g_dbus_interface_vtable, GUINT_TO_POINTER(id),
+	    _g_dbus_object_free, error);
+}
+
+/*
+ * Asynchronous operations
+ */
+
+static void
+_g_async_ready_callback(GObject *source_object, GAsyncResult *res,
+    gpointer user_data)
+{
+	GClosure *closure = (GClosure *)user_data;
+	GValue values[2] = { G_VALUE_INIT, G_VALUE_INIT };
+
+	g_value_init(&values[0], G_TYPE_OBJECT);
+	g_value_set_object(&values[0], source_object);
+	g_value_init(&values[1], G_TYPE_OBJECT);
+	g_value_set_object(&values[1], res);
+	g_closure_invoke(closure, NULL, 2, values, NULL);
+	g_value_unset(&values[0]);
+	g_value_unset(&values[1]);
+	g_closure_unref(closure);
+}
+
+static gpointer
+_g_async_closure(GClosure *closure)
+{
+	g_closure_ref(closure);
+	g_closure_sink(closure);
+	return closure;
+}
+
+/*
+ * Cancellable
+ */
+
+static void
+_g_cancellable_callback(GCancellable *cancellable, gpointer user_data)
+{
+	g_closure_invoke((GClosure *)user_data, NULL, 0, NULL, NULL);
+}
+
+static gulong
+_g_cancellable_connect(GCancellable *cancellable, GClosure *closure)
+{
+	g_closure_ref(closure);
+	g_closure_sink(closure);
+	return g_cancellable_connect(cancellable,
+	    G_CALLBACK(_g_cancellable_callback), closure,
+	    (GDestroyNotify)g_closure_unref);
+}
+
+/*
+ * ListModel
+ */
+
+extern GType goListModelGetItemType(gpointer source);
+extern guint goListModelGetNItems(gpointer source);
+extern gpointer goListModelGetItem(gpointer source, guint position);
+extern void goListModelFree(gpointer source);
+
+typedef struct {
+	GObject		 parent_instance;
+	gpointer	 source;
+} _GoListModel;
+
+typedef struct {
+	GObjectClass	 parent_class;
+} _GoListModelClass;
+
+static gpointer _go_list_model_parent_class = NULL;
+
+static GType
+_go_list_model_get_item_type(GListModel *list)
+{
+	return goListModelGetItemType(((_GoListModel *)list)->source);
+}
+
+static guint
+_go_list_model_get_n_items(GListModel *list)
+{
+	return goListModelGetNItems(((_GoListModel *)list)->source);
+}
+
+static gpointer
+_go_list_model_get_item(GListModel *list, guint position)
+{
+	return goListModelGetItem(((_GoListModel *)list)->source, position);
+}
+
+static void
+_go_list_model_finalize(GObject *object)
+{
+	goListModelFree(((_GoListModel *)object)->source);
+	G_OBJECT_CLASS(_go_list_model_parent_class)->finalize(object);
+}
+
+static void
+_go_list_model_class_init(gpointer klass, gpointer class_data)
+{
+	_go_list_model_parent_class = g_type_class_peek_parent(klass);
+	G_OBJECT_CLASS(klass)->finalize = _go_list_model_finalize;
+}
+
+static void
+_go_list_model_iface_init(gpointer g_iface, gpointer iface_data)
+{
+	GListModelInterface *iface = g_iface;
+
+	iface->get_item_type = _go_list_model_get_item_type;
+	iface->get_n_items = _go_list_model_get_n_items;
+	iface->get_item = _go_list_model_get_item;
+}
+
+static GType
+_go_list_model_get_type(void)
+{
+	static volatile gsize type_id = 0;
+
+	if (g_once_init_enter(&type_id)) {
+		GInterfaceInfo iface_info = {
+			_go_list_model_iface_init, NULL, NULL
+		};
+		GType t = g_type_register_static_simple(G_TYPE_OBJECT,
+		    "GoListModel", sizeof(_GoListModelClass),
+		    _go_list_model_class_init, sizeof(_GoListModel), NULL, 0);
+		g_type_add_interface_static(t, G_TYPE_LIST_MODEL, &iface_info);
+		g_once_init_leave(&type_id, t);
+	}
+	return type_id;
+}
+
+static GListModel *
+_go_list_model_new(gpointer source)
+{
+	_GoListModel *model;
+
+	model = g_object_new(_go_list_model_get_type(), NULL);
+	model->source = source;
+	return G_LIST_MODEL(model);
+}
+
+/*
+ * ListStore
+ */
+
+extern gint goCompareDataFunc(gpointer a, gpointer b, gpointer user_data);
+
+static gint
+_g_compare_data_func(gconstpointer a, gconstpointer b, gpointer user_data)
+{
+	return goCompareDataFunc((gpointer)a, (gpointer)b, user_data);
+}
+
+static void
+_g_list_store_sort(GListStore *store, gpointer user_data)
+{
+	g_list_store_sort(store, _g_compare_data_func, user_data);
+}
+
+static guint
+_g_list_store_insert_sorted(GListStore *store, gpointer item,
+    gpointer user_data)
+{
+	return g_list_store_insert_sorted(store, item, _g_compare_data_func,
+	    user_data);
+}
+
+/*
+ * Sockets
+ */
+
+static gboolean
+_g_is_inet_socket_address(GSocketAddress *address)
+{
+	return G_IS_INET_SOCKET_ADDRESS(address);
+}
+
+static gboolean
+_g_is_unix_socket_address(GSocketAddress *address)
+{
+	return G_IS_UNIX_SOCKET_ADDRESS(address);
+}
@@ -0,0 +1,1335 @@
+package gio
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/dradtke/gotk3/glib"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// testBus starts a private message bus with dbus-daemon and returns its
+// address, skipping t if dbus-daemon is not available.  The bus is
+// stopped when t finishes.
+func testBus(t *testing.T) string {
+	path, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not found")
+	}
+	cmd := exec.Command(path, "--session", "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal("Unable to create pipe:", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skip("Unable to start dbus-daemon:", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatal("Unable to read bus address:", err)
+	}
+	return strings.TrimSpace(address)
+}
+
+// testConnection returns a new connection to the bus at address.
+func testConnection(t *testing.T, address string) *DBusConnection {
+	conn, err := DBusConnectionNewForAddress(address,
+		DBUS_CONNECTION_FLAGS_AUTHENTICATION_CLIENT|
+			DBUS_CONNECTION_FLAGS_MESSAGE_BUS_CONNECTION)
+	if err != nil {
+		t.Fatal("Unable to connect to bus:", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+// runLoop runs the default main loop until quit is called or timeout
+// elapses, and reports whether quit was called.
+func runLoop(t *testing.T, timeout time.Duration, f func(quit func())) bool {
+	loop, err := glib.MainLoopNew(nil)
+	if err != nil {
+		t.Fatal("Unable to create main loop:", err)
+	}
+	done := false
+	f(func() {
+		done = true
+		loop.Quit()
+	})
+	timer := time.AfterFunc(timeout, loop.Quit)
+	defer timer.Stop()
+	loop.Run()
+	return done
+}
+
+func TestDBusCall(t *testing.T) {
+	conn := testConnection(t, testBus(t))
+
+	name, err := glib.VariantNew(conn.GetUniqueName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := conn.Call(context.Background(), "org.freedesktop.DBus",
+		"/org/freedesktop/DBus", "org.freedesktop.DBus", "GetNameOwner",
+		glib.VariantTuple(name))
+	if err != nil {
+		t.Fatal("GetNameOwner failed:", err)
+	}
+	if owner := reply.ChildValue(0).String(); owner != conn.GetUniqueName() {
+		t.Errorf("GetNameOwner returned %q, expected %q", owner,
+			conn.GetUniqueName())
+	}
+
+	_, err = conn.Call(context.Background(), "org.freedesktop.DBus",
+		"/org/freedesktop/DBus", "org.freedesktop.DBus", "NoSuchMethod", nil)
+	if dbusErr, ok := err.(*DBusError); !ok {
+		t.Errorf("Expected a *DBusError, got %v", err)
+	} else if dbusErr.Name != "org.freedesktop.DBus.Error.UnknownMethod" {
+		t.Errorf("Unexpected error name %q", dbusErr.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = conn.Call(ctx, "org.freedesktop.DBus", "/org/freedesktop/DBus",
+		"org.freedesktop.DBus", "ListNames", nil)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDBusSignal(t *testing.T) {
+	conn := testConnection(t, testBus(t))
+
+	var got string
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		id := conn.SignalSubscribe("", "org.gotk3.Test", "Ping",
+			"/org/gotk3/Test", "", DBUS_SIGNAL_FLAGS_NONE,
+			func(_ *DBusConnection, _, _, _, _ string, parameters *glib.Variant) {
+				got = parameters.ChildValue(0).String()
+				quit()
+			})
+		t.Cleanup(func() {
+			conn.SignalUnsubscribe(id)
+		})
+		msg, err := glib.VariantNew("hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = conn.EmitSignal(conn.GetUniqueName(), "/org/gotk3/Test",
+			"org.gotk3.Test", "Ping", glib.VariantTuple(msg))
+		if err != nil {
+			t.Fatal("EmitSignal failed:", err)
+		}
+	})
+	if !ok {
+		t.Fatal("Signal was not received")
+	}
+	if got != "hello" {
+		t.Errorf("Received %q, expected %q", got, "hello")
+	}
+}
+
+type testCalculator struct{}
+
+func (testCalculator) Add(a, b int32) int32 {
+	return a + b
+}
+
+func (testCalculator) Fail() error {
+	return &DBusError{Name: "org.gotk3.Test.Error.Failed", Message: "failed"}
+}
+
+func (testCalculator) Names() []string {
+	return nil
+}
+
+// testNames checks that named slice types are exported as string arrays.
+type testNames []string
+
+func (testCalculator) Sort(names testNames) testNames {
+	sort.Strings(names)
+	return names
+}
+
+func (testCalculator) Nothing() *glib.Variant {
+	return nil
+}
+
+func TestDBusExport(t *testing.T) {
+	address := testBus(t)
+	server := testConnection(t, address)
+	client := testConnection(t, address)
+
+	id, err := server.Export(testCalculator{}, "/org/gotk3/Test",
+		"org.gotk3.Test.Calculator")
+	if err != nil {
+		t.Fatal("Export failed:", err)
+	}
+	defer server.UnregisterObject(id)
+	if _, err := server.Export(testCalculator{}, "/org/gotk3/Test",
+		"org.gotk3.Test.Calculator"); err == nil {
+		t.Error("Exporting the same interface twice succeeded")
+	}
+
+	var (
+		sum        int32
+		names      []string
+		sorted     []string
+		callErr    error
+		nothingErr error
+	)
+	// done is closed once the results are written, so that reading them
+	// after the loop is ordered after the writes.
+	done := make(chan struct{})
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		go func() {
+			defer glib.IdleAdd(func() bool {
+				quit()
+				return false
+			})
+			defer close(done)
+			a, _ := glib.VariantNew(int32(2))
+			b, _ := glib.VariantNew(int32(3))
+			reply, err := client.Call(context.Background(),
+				server.GetUniqueName(), "/org/gotk3/Test",
+				"org.gotk3.Test.Calculator", "Add",
+				glib.VariantTuple(a, b))
+			if err != nil {
+				callErr = err
+				return
+			}
+			sum = reply.ChildValue(0).Int32()
+			reply, err = client.Call(context.Background(),
+				server.GetUniqueName(), "/org/gotk3/Test",
+				"org.gotk3.Test.Calculator", "Names", nil)
+			if err != nil {
+				callErr = err
+				return
+			}
+			names = reply.ChildValue(0).Strv()
+			unsorted, _ := glib.VariantNew([]string{"b", "a"})
+			reply, err = client.Call(context.Background(),
+				server.GetUniqueName(), "/org/gotk3/Test",
+				"org.gotk3.Test.Calculator", "Sort",
+				glib.VariantTuple(unsorted))
+			if err != nil {
+				callErr = err
+				return
+			}
+			sorted = reply.ChildValue(0).Strv()
+			_, nothingErr = client.Call(context.Background(),
+				server.GetUniqueName(), "/org/gotk3/Test",
+				"org.gotk3.Test.Calculator", "Nothing", nil)
+			_, callErr = client.Call(context.Background(),
+				server.GetUniqueName(), "/org/gotk3/Test",
+				"org.gotk3.Test.Calculator", "Fail", nil)
+		}()
+	})
+	if !ok {
+		t.Fatal("Calls did not complete")
+	}
+	<-done
+	if sum != 5 {
+		t.Errorf("Add returned %d, expected 5", sum)
+	}
+	if len(names) != 0 {
+		t.Errorf("Names returned %v, expected an empty list", names)
+	}
+	if !reflect.DeepEqual(sorted, []string{"a", "b"}) {
+		t.Errorf("Sort returned %v, expected [a b]", sorted)
+	}
+	if nothingErr == nil {
+		t.Error("Expected an error for a nil *glib.Variant result")
+	}
+	if dbusErr, ok := callErr.(*DBusError); !ok {
+		t.Errorf("Expected a *DBusError, got %v", callErr)
+	} else if dbusErr.Name != "org.gotk3.Test.Error.Failed" {
+		t.Errorf("Unexpected error name %q", dbusErr.Name)
+	}
+}
+
+func TestDBusWatchName(t *testing.T) {
+	address := testBus(t)
+	owner := testConnection(t, address)
+	watcher := testConnection(t, address)
+
+	var (
+		events []string
+		own    BusNameHandle
+	)
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		w := watcher.WatchName("org.gotk3.Test", BUS_NAME_WATCHER_FLAGS_NONE,
+			func(_ *DBusConnection, name, nameOwner string) {
+				events = append(events, "appeared")
+				if nameOwner != owner.GetUniqueName() {
+					t.Errorf("Name owner is %q, expected %q", nameOwner,
+						owner.GetUniqueName())
+				}
+				own.Unown()
+			},
+			func(_ *DBusConnection, name string) {
+				events = append(events, "vanished")
+				if len(events) == 1 {
+					own = owner.OwnName("org.gotk3.Test",
+						BUS_NAME_OWNER_FLAGS_NONE, nil, nil)
+				} else {
+					quit()
+				}
+			})
+		t.Cleanup(w.Unwatch)
+	})
+	if !ok {
+		t.Fatalf("Watch did not complete; events: %v", events)
+	}
+	expected := []string{"vanished", "appeared", "vanished"}
+	if strings.Join(events, " ") != strings.Join(expected, " ") {
+		t.Errorf("Received events %v, expected %v", events, expected)
+	}
+}
+
+// testCounter serves the org.gotk3.Test.Counter interface described by
+// testCounterXML.
+type testCounter struct {
+	count int32
+}
+
+const testCounterXML = `<node>
+  <interface name="org.gotk3.Test.Counter">
+    <method name="Increment">
+      <arg type="i" direction="out"/>
+    </method>
+    <property name="Count" type="i" access="read"/>
+  </interface>
+</node>`
+
+func (c *testCounter) MethodCall(invocation *DBusMethodInvocation) {
+	c.count++
+	count, _ := glib.VariantNew(c.count)
+	invocation.ReturnValue(glib.VariantTuple(count))
+
+	changed, _ := glib.VariantNew(c.count)
+	props := glib.VariantArray(glib.VariantDictEntry(
+		mustVariant("Count"), mustVariant(changed)))
+	invocation.GetConnection().EmitSignal("", invocation.GetObjectPath(),
+		"org.freedesktop.DBus.Properties", "PropertiesChanged",
+		glib.VariantTuple(mustVariant("org.gotk3.Test.Counter"), props,
+			glib.VariantNewArray(glib.VARIANT_TYPE_STRING)))
+}
+
+func (c *testCounter) GetProperty(sender, objectPath, interfaceName, propertyName string) (*glib.Variant, error) {
+	return glib.VariantNew(c.count)
+}
+
+func (c *testCounter) SetProperty(sender, objectPath, interfaceName, propertyName string, value *glib.Variant) error {
+	return errors.New("read-only property")
+}
+
+func mustVariant(val interface{}) *glib.Variant {
+	v, err := glib.VariantNew(val)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestDBusProxy(t *testing.T) {
+	address := testBus(t)
+	server := testConnection(t, address)
+	client := testConnection(t, address)
+
+	node, err := DBusNodeInfoNewForXML(testCounterXML)
+	if err != nil {
+		t.Fatal("Unable to parse introspection data:", err)
+	}
+	iface, err := node.LookupInterface("org.gotk3.Test.Counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := server.RegisterObject("/org/gotk3/Test", iface,
+		&testCounter{count: 1})
+	if err != nil {
+		t.Fatal("RegisterObject failed:", err)
+	}
+	defer server.UnregisterObject(id)
+
+	// The results are only assigned from the main loop, which runs on
+	// the test's goroutine.
+	var (
+		initial    int32
+		changed    int32
+		proxyErr   error
+		fromSignal int32
+	)
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		go func() {
+			proxy, err := DBusProxyNew(context.Background(), client,
+				DBUS_PROXY_FLAGS_NONE, nil, server.GetUniqueName(),
+				"/org/gotk3/Test", "org.gotk3.Test.Counter")
+			glib.IdleAdd(func() bool {
+				if err != nil {
+					proxyErr = err
+					quit()
+					return false
+				}
+				initial = proxy.GetCachedProperty("Count").Int32()
+				proxy.OnPropertiesChanged(func(props map[string]*glib.Variant, _ []string) {
+					fromSignal = props["Count"].Int32()
+					changed = proxy.GetCachedProperty("Count").Int32()
+					quit()
+				})
+				go func() {
+					if _, err := proxy.Call(context.Background(),
+						"Increment", nil); err != nil {
+						glib.IdleAdd(func() bool {
+							proxyErr = err
+							quit()
+							return false
+						})
+					}
+				}()
+				return false
+			})
+		}()
+	})
+	if proxyErr != nil {
+		t.Fatal("Proxy failed:", proxyErr)
+	}
+	if !ok {
+		t.Fatal("Properties change was not received")
+	}
+	if initial != 1 {
+		t.Errorf("Initial Count is %d, expected 1", initial)
+	}
+	if fromSignal != 2 || changed != 2 {
+		t.Errorf("Changed Count is %d (cached %d), expected 2", fromSignal,
+			changed)
+	}
+}
+
+const testSchemaXML = `<schemalist>
+  <schema id="org.gotk3.Test" path="/org/gotk3/Test/">
+    <key name="name" type="s">
+      <default>"gotk3"</default>
+      <summary>Name</summary>
+    </key>
+    <key name="count" type="i">
+      <range min="0" max="10"/>
+      <default>3</default>
+    </key>
+    <key name="enabled" type="b">
+      <default>false</default>
+    </key>
+    <key name="tags" type="as">
+      <default>[]</default>
+    </key>
+  </schema>
+</schemalist>`
+
+// testSchema compiles testSchemaXML and returns its schema, skipping t if
+// glib-compile-schemas is not available.
+func testSchema(t *testing.T) *SettingsSchema {
+	path, err := exec.LookPath("glib-compile-schemas")
+	if err != nil {
+		t.Skip("glib-compile-schemas not found")
+	}
+	dir := t.TempDir()
+	err = ioutil.WriteFile(filepath.Join(dir, "org.gotk3.Test.gschema.xml"),
+		[]byte(testSchemaXML), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command(path, dir).CombinedOutput(); err != nil {
+		t.Fatalf("glib-compile-schemas failed: %v: %s", err, out)
+	}
+	source, err := SettingsSchemaSourceNewFromDirectory(dir, nil, true)
+	if err != nil {
+		t.Fatal("Unable to load schemas:", err)
+	}
+	schema, err := source.Lookup("org.gotk3.Test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestSettings(t *testing.T) {
+	schema := testSchema(t)
+	backend, err := MemorySettingsBackendNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := SettingsNewFull(schema, backend, "")
+	if err != nil {
+		t.Fatal("Unable to create settings:", err)
+	}
+
+	if name := settings.GetString("name"); name != "gotk3" {
+		t.Errorf("Default name is %q, expected %q", name, "gotk3")
+	}
+	if !settings.SetString("name", "test") || settings.GetString("name") != "test" {
+		t.Error("Could not set or get name")
+	}
+	settings.Reset("name")
+	if name := settings.GetString("name"); name != "gotk3" {
+		t.Errorf("Reset name is %q, expected %q", name, "gotk3")
+	}
+
+	var changed []string
+	settings.OnChanged("count", func(key string) {
+		changed = append(changed, key)
+	})
+	if !settings.SetInt("count", 5) || settings.GetInt("count") != 5 {
+		t.Error("Could not set or get count")
+	}
+	if !reflect.DeepEqual(changed, []string{"count"}) {
+		t.Errorf("Received changes %v, expected [count]", changed)
+	}
+
+	settings.Delay()
+	settings.SetBoolean("enabled", true)
+	if !settings.GetHasUnapplied() {
+		t.Error("Delayed change is not unapplied")
+	}
+	settings.Revert()
+	if settings.GetBoolean("enabled") {
+		t.Error("Reverted change was applied")
+	}
+	settings.SetStrv("tags", []string{"a", "b"})
+	settings.Apply()
+	if tags := settings.GetStrv("tags"); !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Errorf("Tags are %v, expected [a b]", tags)
+	}
+}
+
+func TestSettingsSchema(t *testing.T) {
+	schema := testSchema(t)
+
+	keys := schema.ListKeys()
+	if len(keys) != 4 {
+		t.Errorf("Schema has keys %v, expected 4", keys)
+	}
+	key, err := schema.GetKey("count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ := key.GetValueType(); typ != "i" {
+		t.Errorf("Key type is %q, expected %q", typ, "i")
+	}
+	if def := key.GetDefaultValue().Int32(); def != 3 {
+		t.Errorf("Key default is %d, expected 3", def)
+	}
+	if kind := key.GetRange().ChildValue(0).String(); kind != "range" {
+		t.Errorf("Key range kind is %q, expected %q", kind, "range")
+	}
+	if key.RangeCheck(mustVariant(int32(11))) {
+		t.Error("Out of range value passed range check")
+	}
+	if _, err := schema.GetKey("missing"); err == nil {
+		t.Error("Missing key was found")
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir, err := FileNewForPath(t.TempDir())
+	if err != nil {
+		t.Fatal("Unable to create file:", err)
+	}
+	sub, err := dir.GetChild("sub")
+	if err != nil {
+		t.Fatal("Unable to get child:", err)
+	}
+	if err := sub.MakeDirectory(); err != nil {
+		t.Fatal("Unable to make directory:", err)
+	}
+	if typ := sub.QueryFileType(FILE_QUERY_INFO_NONE); typ != FILE_TYPE_DIRECTORY {
+		t.Errorf("Expected directory, got file type %d", typ)
+	}
+
+	file, _ := sub.GetChild("hello.txt")
+	out, err := file.Replace("", false, FILE_CREATE_NONE)
+	if err != nil {
+		t.Fatal("Unable to replace file:", err)
+	}
+	if _, err := io.WriteString(out, "hello, world"); err != nil {
+		t.Fatal("Unable to write:", err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal("Unable to close output stream:", err)
+	}
+
+	in, err := file.Read()
+	if err != nil {
+		t.Fatal("Unable to read file:", err)
+	}
+	b, err := ioutil.ReadAll(in)
+	in.Close()
+	if err != nil || string(b) != "hello, world" {
+		t.Errorf("Expected %q, got %q (%v)", "hello, world", b, err)
+	}
+
+	info, err := file.QueryInfo("standard::*,time::*", FILE_QUERY_INFO_NONE)
+	if err != nil {
+		t.Fatal("Unable to query info:", err)
+	}
+	if info.GetName() != "hello.txt" || info.GetSize() != 12 {
+		t.Errorf("Unexpected info: name %q, size %d", info.GetName(),
+			info.GetSize())
+	}
+	path, err := file.GetPath()
+	if err != nil {
+		t.Fatal("Unable to get path:", err)
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		t.Fatal("Unable to stat file:", err)
+	}
+	if mtime, ok := info.GetModificationTime(); !ok {
+		t.Error("Modification time was not queried")
+	} else if !mtime.Equal(st.ModTime().Truncate(time.Microsecond)) {
+		t.Errorf("Expected modification time %v, got %v", st.ModTime(), mtime)
+	}
+	nameOnly, err := file.QueryInfo("standard::name", FILE_QUERY_INFO_NONE)
+	if err != nil {
+		t.Fatal("Unable to query info:", err)
+	}
+	if _, ok := nameOnly.GetModificationTime(); ok {
+		t.Error("Got a modification time that was not queried")
+	}
+
+	copied, _ := sub.GetChild("copy.txt")
+	if err := file.Copy(copied, FILE_COPY_NONE); err != nil {
+		t.Fatal("Unable to copy file:", err)
+	}
+	e, err := sub.EnumerateChildren("standard::name", FILE_QUERY_INFO_NONE)
+	if err != nil {
+		t.Fatal("Unable to enumerate children:", err)
+	}
+	var names []string
+	for {
+		info, err := e.NextFile()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal("Unable to get next file:", err)
+		}
+		names = append(names, info.GetName())
+	}
+	e.Close()
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"copy.txt", "hello.txt"}) {
+		t.Errorf("Unexpected children: %v", names)
+	}
+
+	if err := copied.Delete(); err != nil {
+		t.Fatal("Unable to delete file:", err)
+	}
+	if copied.QueryExists() {
+		t.Error("Deleted file still exists")
+	}
+	if err := copied.Delete(); err == nil {
+		t.Error("Expected an error deleting a missing file")
+	}
+}
+
+func TestFileAsync(t *testing.T) {
+	file, err := FileNewForPath(filepath.Join(t.TempDir(), "async.txt"))
+	if err != nil {
+		t.Fatal("Unable to create file:", err)
+	}
+	if err := file.ReplaceContents([]byte("async"), "", false, FILE_CREATE_NONE); err != nil {
+		t.Fatal("Unable to replace contents:", err)
+	}
+
+	var (
+		contents []byte
+		loadErr  error
+	)
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		file.LoadContentsAsync(context.Background(), func(b []byte, err error) {
+			contents, loadErr = b, err
+			quit()
+		})
+	})
+	if !ok {
+		t.Fatal("Timed out loading contents")
+	}
+	if loadErr != nil || string(contents) != "async" {
+		t.Errorf("Expected %q, got %q (%v)", "async", contents, loadErr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ok = runLoop(t, 5*time.Second, func(quit func()) {
+		file.QueryInfoAsync(ctx, "standard::*", FILE_QUERY_INFO_NONE,
+			func(info *FileInfo, err error) {
+				loadErr = err
+				quit()
+			})
+	})
+	if !ok {
+		t.Fatal("Timed out querying info")
+	}
+	if loadErr != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", loadErr)
+	}
+}
+
+func TestFileMonitor(t *testing.T) {
+	dir, err := FileNewForPath(t.TempDir())
+	if err != nil {
+		t.Fatal("Unable to create file:", err)
+	}
+	monitor, err := dir.MonitorDirectory(FILE_MONITOR_NONE)
+	if err != nil {
+		t.Fatal("Unable to monitor directory:", err)
+	}
+	defer monitor.Cancel()
+
+	file, _ := dir.GetChild("created.txt")
+	var created *File
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		monitor.OnChanged(func(_ *FileMonitor, f, other *File, event FileMonitorEvent) {
+			if event == FILE_MONITOR_EVENT_CREATED {
+				created = f
+				quit()
+			}
+		})
+		if err := file.ReplaceContents([]byte("x"), "", false, FILE_CREATE_NONE); err != nil {
+			t.Fatal("Unable to create file:", err)
+		}
+	})
+	if !ok {
+		t.Fatal("Timed out waiting for a created event")
+	}
+	if !created.Equal(file) {
+		t.Errorf("Expected event for %s, got %s", file.GetURI(),
+			created.GetURI())
+	}
+}
+
+func TestCancellableFromContext(t *testing.T) {
+	if c, release := CancellableFromContext(context.Background()); c != nil {
+		release()
+		t.Fatal("Expected a nil Cancellable for an uncancellable context")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, release := CancellableFromContext(ctx)
+	defer release()
+	if c.IsCancelled() {
+		t.Fatal("Cancellable cancelled before its context")
+	}
+	done := make(chan struct{})
+	if id := c.Connect(func() { close(done) }); id == 0 {
+		t.Fatal("Expected a handler id from Connect")
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for cancellation")
+	}
+	if !c.IsCancelled() {
+		t.Error("Cancellable not cancelled after its context")
+	}
+
+	called := false
+	if id := c.Connect(func() { called = true }); id != 0 || !called {
+		t.Errorf("Expected an immediate call for a cancelled Cancellable, got id %d", id)
+	}
+}
+
+func TestResourceNewFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"style.css":   {Data: []byte("label { color: red; }")},
+		"ui/main.ui":  {Data: []byte("<interface/>")},
+		"ui/empty.ui": {Data: []byte{}},
+	}
+	res, err := ResourceNewFromFS(fsys, "/org/gotk3/test")
+	if err != nil {
+		t.Fatal("Unable to create resource:", err)
+	}
+
+	data, err := res.LookupData("/org/gotk3/test/ui/main.ui", RESOURCE_LOOKUP_FLAGS_NONE)
+	if err != nil || string(data) != "<interface/>" {
+		t.Errorf("Expected %q, got %q (%v)", "<interface/>", data, err)
+	}
+	data, err = res.LookupData("/org/gotk3/test/ui/empty.ui", RESOURCE_LOOKUP_FLAGS_NONE)
+	if err != nil || len(data) != 0 {
+		t.Errorf("Expected empty data, got %q (%v)", data, err)
+	}
+	if _, err := res.LookupData("/org/gotk3/test/missing", RESOURCE_LOOKUP_FLAGS_NONE); err == nil {
+		t.Error("Expected an error looking up a missing resource")
+	}
+
+	children, err := res.EnumerateChildren("/org/gotk3/test", RESOURCE_LOOKUP_FLAGS_NONE)
+	if err != nil {
+		t.Fatal("Unable to enumerate children:", err)
+	}
+	sort.Strings(children)
+	if !reflect.DeepEqual(children, []string{"style.css", "ui/"}) {
+		t.Errorf("Unexpected children: %v", children)
+	}
+
+	res.Register()
+	defer res.Unregister()
+	stream, err := ResourcesOpenStream("/org/gotk3/test/style.css", RESOURCE_LOOKUP_FLAGS_NONE)
+	if err != nil {
+		t.Fatal("Unable to open stream:", err)
+	}
+	defer stream.Close()
+	data, err = ioutil.ReadAll(stream)
+	if err != nil || string(data) != "label { color: red; }" {
+		t.Errorf("Unexpected stream contents %q (%v)", data, err)
+	}
+}
+
+func TestIcon(t *testing.T) {
+	themed, err := ThemedIconNewFromNames([]string{"text-x-generic", "text-plain"})
+	if err != nil {
+		t.Fatal("Unable to create themed icon:", err)
+	}
+	str, err := themed.ToString()
+	if err != nil {
+		t.Fatal("Unable to convert icon to string:", err)
+	}
+	icon, err := IconNewForString(str)
+	if err != nil {
+		t.Fatal("Unable to create icon from string:", err)
+	}
+	if !icon.Equal(themed) {
+		t.Errorf("Icon from %q not equal to the original", str)
+	}
+
+	emblem, err := EmblemNew(themed)
+	if err != nil {
+		t.Fatal("Unable to create emblem:", err)
+	}
+	base, _ := ThemedIconNew("folder")
+	emblemed, err := EmblemedIconNew(base, emblem)
+	if err != nil {
+		t.Fatal("Unable to create emblemed icon:", err)
+	}
+	if !emblemed.GetIcon().Equal(base) {
+		t.Error("Emblemed icon has the wrong base icon")
+	}
+	if emblems := emblemed.GetEmblems(); len(emblems) != 1 || !emblems[0].GetIcon().Equal(themed) {
+		t.Errorf("Unexpected emblems: %v", emblems)
+	}
+
+	data := []byte("<svg xmlns='http://www.w3.org/2000/svg'/>")
+	bytes, err := BytesIconNew(data)
+	if err != nil {
+		t.Fatal("Unable to create bytes icon:", err)
+	}
+	if got := bytes.GetBytes(); string(got) != string(data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+}
+
+func actionNames(t *testing.T, model *ListModel) []string {
+	var names []string
+	for i := uint(0); i < model.GetNItems(); i++ {
+		item, err := model.GetItem(i)
+		if err != nil {
+			t.Fatal("Unable to get item:", err)
+		}
+		names = append(names, (&Action{item}).GetName())
+	}
+	return names
+}
+
+func TestListStore(t *testing.T) {
+	store, err := ListStoreNew(glib.TYPE_OBJECT)
+	if err != nil {
+		t.Fatal("Unable to create list store:", err)
+	}
+	var changes [][3]uint
+	store.OnItemsChanged(func(position, removed, added uint) {
+		changes = append(changes, [3]uint{position, removed, added})
+	})
+
+	for _, name := range []string{"c", "a", "d"} {
+		action, _ := SimpleActionNew(name, "")
+		store.Append(action)
+	}
+	b, _ := SimpleActionNew("b", "")
+	store.Splice(1, 1, []glib.IObject{b})
+	if names := actionNames(t, &store.ListModel); !reflect.DeepEqual(names, []string{"c", "b", "d"}) {
+		t.Errorf("Unexpected items after splice: %v", names)
+	}
+
+	byName := func(x, y *glib.Object) int {
+		return strings.Compare((&Action{x}).GetName(), (&Action{y}).GetName())
+	}
+	store.Sort(byName)
+	a, _ := SimpleActionNew("a", "")
+	if pos := store.InsertSorted(a, byName); pos != 0 {
+		t.Errorf("Expected sorted insertion at 0, got %d", pos)
+	}
+	if names := actionNames(t, &store.ListModel); !reflect.DeepEqual(names, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Unexpected items after sort: %v", names)
+	}
+
+	if len(changes) <= 3 || changes[3] != [3]uint{1, 1, 1} {
+		t.Errorf("Unexpected items-changed emissions: %v", changes)
+	}
+	if _, err := store.GetItem(10); err == nil {
+		t.Error("Expected an error getting an out of range item")
+	}
+}
+
+type testActions []*SimpleAction
+
+func (s *testActions) GetItemType() glib.Type { return glib.TYPE_OBJECT }
+func (s *testActions) GetNItems() uint        { return uint(len(*s)) }
+
+func (s *testActions) GetItem(position uint) glib.IObject {
+	if position >= uint(len(*s)) {
+		return nil
+	}
+	return (*s)[position]
+}
+
+func TestListModelNew(t *testing.T) {
+	actions := &testActions{}
+	model, err := ListModelNew(actions)
+	if err != nil {
+		t.Fatal("Unable to create list model:", err)
+	}
+	if model.GetItemType() != glib.TYPE_OBJECT {
+		t.Errorf("Unexpected item type %v", model.GetItemType())
+	}
+
+	added := uint(0)
+	model.OnItemsChanged(func(position, removed, n uint) {
+		added += n
+	})
+	for _, name := range []string{"x", "y"} {
+		action, _ := SimpleActionNew(name, "")
+		*actions = append(*actions, action)
+	}
+	model.ItemsChanged(0, 0, 2)
+	if added != 2 {
+		t.Errorf("Expected 2 added items, got %d", added)
+	}
+	if names := actionNames(t, model); !reflect.DeepEqual(names, []string{"x", "y"}) {
+		t.Errorf("Unexpected items: %v", names)
+	}
+	if _, err := model.GetItem(2); err == nil {
+		t.Error("Expected an error getting an out of range item")
+	}
+}
+
+func TestSubprocess(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+	p, err := SubprocessNew(SUBPROCESS_FLAGS_STDIN_PIPE|SUBPROCESS_FLAGS_STDOUT_PIPE|SUBPROCESS_FLAGS_STDERR_PIPE,
+		"sh", "-c", "cat; echo oops >&2")
+	if err != nil {
+		t.Fatal("Unable to start subprocess:", err)
+	}
+	if _, err := SubprocessNew(SUBPROCESS_FLAGS_NONE); err == nil {
+		t.Error("Expected an error for an empty argv")
+	}
+	var stdout, stderr string
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		p.CommunicateUTF8Async(context.Background(), "hello",
+			func(out, errOut string, e error) {
+				stdout, stderr, err = out, errOut, e
+				quit()
+			})
+	})
+	if !ok {
+		t.Fatal("Timed out communicating with subprocess")
+	}
+	if err != nil || stdout != "hello" || stderr != "oops\n" {
+		t.Errorf("Unexpected output %q, %q (%v)", stdout, stderr, err)
+	}
+	if !p.GetSuccessful() {
+		t.Error("Expected the subprocess to exit successfully")
+	}
+
+	p, err = SubprocessNew(SUBPROCESS_FLAGS_NONE, "sh", "-c", "exit 3")
+	if err != nil {
+		t.Fatal("Unable to start subprocess:", err)
+	}
+	ok = runLoop(t, 5*time.Second, func(quit func()) {
+		p.WaitCheckAsync(context.Background(), func(e error) {
+			err = e
+			quit()
+		})
+	})
+	if !ok {
+		t.Fatal("Timed out waiting for subprocess")
+	}
+	if err == nil || p.GetExitStatus() != 3 {
+		t.Errorf("Expected exit status 3, got %d (%v)", p.GetExitStatus(), err)
+	}
+}
+
+func TestSubprocessLauncher(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+	dir := t.TempDir()
+	launcher, err := SubprocessLauncherNew(SUBPROCESS_FLAGS_STDOUT_PIPE)
+	if err != nil {
+		t.Fatal("Unable to create launcher:", err)
+	}
+	if _, err := launcher.Spawn(); err == nil {
+		t.Error("Expected an error spawning an empty argv")
+	}
+	launcher.Setenv("GOTK3_TEST", "value", true)
+	if val, ok := launcher.Getenv("GOTK3_TEST"); !ok || val != "value" {
+		t.Errorf("Expected %q, got %q", "value", val)
+	}
+	launcher.SetCwd(dir)
+	p, err := launcher.Spawn("sh", "-c", "echo $GOTK3_TEST; pwd")
+	if err != nil {
+		t.Fatal("Unable to spawn subprocess:", err)
+	}
+	stdout, err := p.GetStdoutPipe()
+	if err != nil {
+		t.Fatal("Unable to get stdout pipe:", err)
+	}
+	out, err := ioutil.ReadAll(stdout)
+	if err != nil {
+		t.Fatal("Unable to read stdout:", err)
+	}
+	if err := p.WaitCheck(context.Background()); err != nil {
+		t.Fatal("Subprocess failed:", err)
+	}
+	if lines := strings.Fields(string(out)); len(lines) != 2 || lines[0] != "value" || filepath.Clean(lines[1]) != filepath.Clean(dir) {
+		t.Errorf("Unexpected output %q", out)
+	}
+}
+
+const testNotificationsXML = `<node>
+  <interface name="org.freedesktop.Notifications">
+    <method name="Notify">
+      <arg type="s" direction="in"/>
+      <arg type="u" direction="in"/>
+      <arg type="s" direction="in"/>
+      <arg type="s" direction="in"/>
+      <arg type="s" direction="in"/>
+      <arg type="as" direction="in"/>
+      <arg type="a{sv}" direction="in"/>
+      <arg type="i" direction="in"/>
+      <arg type="u" direction="out"/>
+    </method>
+    <method name="CloseNotification">
+      <arg type="u" direction="in"/>
+    </method>
+  </interface>
+</node>`
+
+// testNotifications is a fake org.freedesktop.Notifications service,
+// which calls notify with the summary, body and actions of each
+// notification once it has replied with the notification's id, and close
+// with the id of each closed notification.
+type testNotifications struct {
+	notify func(summary, body string, actions []string)
+	close  func(id uint32)
+}
+
+func (n *testNotifications) MethodCall(invocation *DBusMethodInvocation) {
+	params := invocation.GetParameters()
+	switch invocation.GetMethodName() {
+	case "Notify":
+		id, _ := glib.VariantNew(uint32(1))
+		invocation.ReturnValue(glib.VariantTuple(id))
+		n.notify(params.ChildValue(3).String(), params.ChildValue(4).String(),
+			params.ChildValue(5).Strv())
+	case "CloseNotification":
+		invocation.ReturnValue(nil)
+		n.close(params.ChildValue(0).Uint32())
+	}
+}
+
+func (n *testNotifications) GetProperty(sender, objectPath, interfaceName, propertyName string) (*glib.Variant, error) {
+	return nil, errors.New("no properties")
+}
+
+func (n *testNotifications) SetProperty(sender, objectPath, interfaceName, propertyName string, value *glib.Variant) error {
+	return errors.New("no properties")
+}
+
+func TestSendNotification(t *testing.T) {
+	address := testBus(t)
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", address)
+	t.Setenv("GNOTIFICATION_BACKEND", "freedesktop")
+	server := testConnection(t, address)
+
+	node, err := DBusNodeInfoNewForXML(testNotificationsXML)
+	if err != nil {
+		t.Fatal("Unable to parse introspection data:", err)
+	}
+	iface, _ := node.LookupInterface("org.freedesktop.Notifications")
+	service := &testNotifications{}
+	id, err := server.RegisterObject("/org/freedesktop/Notifications", iface,
+		service)
+	if err != nil {
+		t.Fatal("RegisterObject failed:", err)
+	}
+	defer server.UnregisterObject(id)
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		server.OwnName("org.freedesktop.Notifications",
+			BUS_NAME_OWNER_FLAGS_NONE,
+			func(*DBusConnection, string) { quit() }, nil)
+	})
+	if !ok {
+		t.Fatal("Timed out owning the notifications name")
+	}
+
+	app, err := ApplicationNew("org.gotk3.Test.Notifications", FLAGS_NONE)
+	if err != nil {
+		t.Fatal("Unable to create application:", err)
+	}
+	if err := app.Register(context.Background()); err != nil {
+		t.Fatal("Unable to register application:", err)
+	}
+
+	n, err := NotificationNew("Build finished")
+	if err != nil {
+		t.Fatal("Unable to create notification:", err)
+	}
+	n.SetBody("All tests passed")
+	n.SetPriority(NOTIFICATION_PRIORITY_HIGH)
+	n.SetDefaultAction("app.show")
+	n.AddButtonWithTarget("Open log", "app.open", mustVariant("/tmp/build.log"))
+
+	var (
+		summary, body string
+		actions       []string
+		closed        bool
+	)
+	ok = runLoop(t, 5*time.Second, func(quit func()) {
+		service.notify = func(s, b string, a []string) {
+			summary, body, actions = s, b, a
+			quit()
+		}
+		app.SendNotification("build", n)
+	})
+	if !ok {
+		t.Fatal("Timed out waiting for the notification")
+	}
+	if summary != "Build finished" || body != "All tests passed" {
+		t.Errorf("Unexpected notification %q, %q", summary, body)
+	}
+	if len(actions) != 4 || actions[1] != "" || actions[3] != "Open log" {
+		t.Errorf("Unexpected actions %q", actions)
+	}
+
+	// The id assigned by the service must be received before the
+	// notification can be closed.  The service has already sent it, so a
+	// round trip to the service on the application's connection ensures
+	// that the reply has reached the application, and its handler is
+	// dispatched ahead of the idle callback below.
+	conn, err := app.GetDBusConnection()
+	if err != nil {
+		t.Fatal("Unable to get the application's connection:", err)
+	}
+	if _, err := conn.Call(context.Background(),
+		"org.freedesktop.Notifications", "/org/freedesktop/Notifications",
+		"org.freedesktop.DBus.Peer", "Ping", nil); err != nil {
+		t.Fatal("Unable to ping the notifications service:", err)
+	}
+	ok = runLoop(t, 5*time.Second, func(quit func()) {
+		service.close = func(uint32) {
+			closed = true
+			quit()
+		}
+		glib.IdleAdd(func() bool {
+			app.WithdrawNotification("build")
+			return false
+		})
+	})
+	if !ok || !closed {
+		t.Error("Timed out waiting for the notification to be withdrawn")
+	}
+}
+
+func TestAppInfo(t *testing.T) {
+	info, err := AppInfoCreateFromCommandline("true %u", "gotk3-test", APP_INFO_CREATE_SUPPORTS_URIS)
+	if err != nil {
+		t.Fatal("Unable to create app info:", err)
+	}
+	if name := info.GetName(); name != "gotk3-test" {
+		t.Errorf("Expected name %q, got %q", "gotk3-test", name)
+	}
+	if !info.SupportsURIs() {
+		t.Error("App info does not support URIs")
+	}
+
+	ctx, err := AppLaunchContextNew()
+	if err != nil {
+		t.Fatal("Unable to create launch context:", err)
+	}
+	ctx.Setenv("GOTK3_TEST", "1")
+	found := false
+	for _, env := range ctx.GetEnvironment() {
+		if env == "GOTK3_TEST=1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Launch context environment is missing GOTK3_TEST")
+	}
+	if err := info.LaunchURIs([]string{"file:///"}, ctx); err != nil {
+		t.Error("Unable to launch:", err)
+	}
+}
+
+func TestSocketService(t *testing.T) {
+	service, err := SocketServiceNew()
+	if err != nil {
+		t.Fatal("Unable to create socket service:", err)
+	}
+	defer service.Close()
+	port, err := service.AddAnyInetPort()
+	if err != nil {
+		t.Fatal("Unable to listen:", err)
+	}
+	service.OnIncoming(func(conn *SocketConnection) bool {
+		conn.GetInputStream().ReadAsync(context.Background(), 64, func(data []byte, err error) {
+			conn.GetOutputStream().WriteAllAsync(context.Background(), data, func(int, error) {
+				conn.Close()
+			})
+		})
+		return true
+	})
+
+	var (
+		reply   []byte
+		loopErr error
+		remote  net.Addr
+	)
+	client, _ := SocketClientNew()
+	client.SetTimeout(500 * time.Millisecond)
+	if timeout := client.GetTimeout(); timeout != time.Second {
+		t.Errorf("Expected a sub-second timeout to round up to 1s, got %v", timeout)
+	}
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		client.ConnectToHostAsync(context.Background(), "127.0.0.1", port, func(conn *SocketConnection, err error) {
+			if err != nil {
+				loopErr = err
+				quit()
+				return
+			}
+			remote = conn.RemoteAddr()
+			conn.GetOutputStream().WriteAllAsync(context.Background(), []byte("ping"), func(_ int, err error) {
+				conn.GetInputStream().ReadAsync(context.Background(), 64, func(data []byte, err error) {
+					reply, loopErr = data, err
+					quit()
+				})
+			})
+		})
+	})
+	if !ok {
+		t.Fatal("Timed out waiting for echo")
+	}
+	if loopErr != nil || string(reply) != "ping" {
+		t.Errorf("Expected %q, got %q (%v)", "ping", reply, loopErr)
+	}
+	if want := net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port))); remote == nil || remote.String() != want {
+		t.Errorf("Expected remote address %s, got %v", want, remote)
+	}
+}
+
+func TestUnixSocketAddress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "socket")
+	address, err := UnixSocketAddressNew(path)
+	if err != nil {
+		t.Fatal("Unable to create address:", err)
+	}
+	if got := address.GetPath(); got != path {
+		t.Errorf("Expected path %q, got %q", path, got)
+	}
+
+	service, _ := SocketServiceNew()
+	defer service.Close()
+	if _, err := service.AddAddress(address); err != nil {
+		t.Fatal("Unable to listen:", err)
+	}
+	// The loop quits once the service has read the client's message and
+	// the client has finished.
+	var (
+		received  []byte
+		clientErr error
+	)
+	ok := runLoop(t, 5*time.Second, func(quit func()) {
+		pending := 2
+		finish := func() {
+			if pending--; pending == 0 {
+				quit()
+			}
+		}
+		service.OnIncoming(func(conn *SocketConnection) bool {
+			conn.GetInputStream().ReadAsync(context.Background(), 64, func(data []byte, err error) {
+				received = data
+				finish()
+			})
+			return true
+		})
+
+		// The client side uses SocketConnection as a net.Conn from
+		// another goroutine while the service runs on the main loop.
+		go func() {
+			err := unixClient(address)
+			glib.IdleAdd(func() bool {
+				clientErr = err
+				finish()
+				return false
+			})
+		}()
+	})
+	if !ok {
+		t.Fatal("Timed out waiting for the client and service")
+	}
+	if string(received) != "hello" {
+		t.Errorf("Expected %q, got %q", "hello", received)
+	}
+	if clientErr != os.ErrDeadlineExceeded {
+		t.Errorf("Expected os.ErrDeadlineExceeded, got %v", clientErr)
+	}
+}
+
+// unixClient connects to address, writes "hello" and then waits for a
+// reply which never comes, returning the error from the final Read().
+// The read deadline is set while that Read() is already blocked.
+func unixClient(address *UnixSocketAddress) error {
+	client, _ := SocketClientNew()
+	c, err := client.Connect(context.Background(), address)
+	if err != nil {
+		return err
+	}
+	var conn net.Conn = c
+	defer conn.Close()
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		return err
+	}
+	time.AfterFunc(50*time.Millisecond, func() {
+		conn.SetReadDeadline(time.Now())
+	})
+	_, err = conn.Read(make([]byte, 1))
+	return err
+}
@@ -17,7 +17,7 @@
  */
 
 /*
-Go bindings for GLib 2.  Supports version 2.36 and later.
+Go bindings for GLib 2.  Supports version 2.46 and later.
 */
 package glib
 
@@ -62,6 +62,33 @@ func gobool(b C.gboolean) bool {
 	return false
 }
 
+// goStrv() converts a NULL-terminated array of C strings to a Go slice.
+func goStrv(c **C.gchar) []string {
+	var strs []string
+	if c == nil {
+		return strs
+	}
+	for p := c; *p != nil; p = (**C.gchar)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + unsafe.Sizeof(*p))) {
+		strs = append(strs, C.GoString((*C.char)(*p)))
+	}
+	return strs
+}
+
+// goError() converts a GError to a Go error and frees the GError.
+func goError(err *C.GError) error {
+	defer C.g_error_free(err)
+	return errors.New(C.GoString((*C.char)(C.error_get_message(err))))
+}
+
+// cStringOrNil() returns a C copy of s, or nil if s is empty.  A non-nil
+// result must be freed with C.free().
+func cStringOrNil(s string) *C.gchar {
+	if s == "" {
+		return nil
+	}
+	return (*C.gchar)(C.CString(s))
+}
+
 /*
  * Unexported vars
  */
@@ -72,7 +99,10 @@ var (
 		sync.RWMutex
 		m map[*C.GClosure]reflect.Value
 	}{}
-	signals = make(map[SignalHandle]*C.GClosure)
+	signals = struct {
+		sync.Mutex
+		m map[SignalHandle]*C.GClosure
+	}{m: make(map[SignalHandle]*C.GClosure)}
 )
 
 /*
@@ -88,6 +118,17 @@ func ClosureNew(f interface{}) *C.GClosure {
 	return closure
 }
 
+// removeClosure() is called by the GLib runtime when a closure created by
+// ClosureNew() is finalized, and removes its callback function from the
+// internally-maintained map.
+//
+//export removeClosure
+func removeClosure(data C.gpointer, closure *C.GClosure) {
+	closures.Lock()
+	delete(closures.m, closure)
+	closures.Unlock()
+}
+
 /*
  * Constants
  */
@@ -161,36 +202,64 @@ func (v *Object) Connect(detailed_signal string, f interface{}) SignalHandle {
 	closure := ClosureNew(f)
 	c := C.g_signal_connect_closure(C.gpointer(v.Native()), (*C.gchar)(cstr), closure, gbool(false))
 	h := SignalHandle(c)
+	signals.Lock()
+	signals.m[h] = closure
+	signals.Unlock()
 	return h
 }
 
 // goMarshal() is called by the GLib runtime when a closure needs to be invoked.
 // The closure will be invoked with as many arguments as it can take, from 0 to
 // the full amount provided by the call. If the closure asks for more parameters
-// than there are to give, this method panics.
+// than there are to give, this method panics.  Variadic closures are
+// always invoked with every argument provided by the call.  Arguments
+// which GoValue() cannot convert, such as boxed types, are passed as a
+// *Value holding a copy of the argument.
 //
 //export goMarshal
 func goMarshal(closure *C.GClosure, return_value *C.GValue, n_param_values C.guint, param_values *C.GValue, invocation_hint C.gpointer, marshal_data C.gpointer) {
+	closures.RLock()
+	callback := closures.m[closure]
+	closures.RUnlock()
+
 	var (
 		go_params []reflect.Value
 		ret       []reflect.Value
-		callback  = closures.m[closure]
-		numIn     = callback.Type().NumIn()
+		cbType    = callback.Type()
+		numIn     = cbType.NumIn()
 		numParams = int(n_param_values)
 	)
-	if numIn == 0 {
+	if cbType.IsVariadic() && numIn-1 <= numParams {
+		params := valueSlice(numParams, param_values)
+		go_params = make([]reflect.Value, numParams)
+		for i := 0; i < numParams; i++ {
+			val := marshalParam(&params[i])
+			var t reflect.Type
+			if i < numIn-1 {
+				t = cbType.In(i)
+			} else {
+				t = cbType.In(numIn - 1).Elem()
+			}
+			if val == nil {
+				go_params[i] = reflect.Zero(t)
+			} else {
+				go_params[i] = reflect.ValueOf(val)
+			}
+		}
+		ret = callback.Call(go_params)
+	} else if numIn == 0 {
 		go_params = make([]reflect.Value, 0)
 		ret = callback.Call(go_params)
 	} else if numIn <= numParams {
 		params := valueSlice(numParams, param_values)
 		go_params = make([]reflect.Value, numIn)
 		for i := 0; i < numIn; i++ {
-			v := &Value{params[i]}
-			val, err := v.GoValue()
-			if err != nil {
-				panic(err)
+			val := marshalParam(&params[i])
+			if val == nil {
+				go_params[i] = reflect.Zero(cbType.In(i))
+			} else {
+				go_params[i] = reflect.ValueOf(val)
 			}
-			go_params[i] = reflect.ValueOf(val)
 		}
 		ret = callback.Call(go_params)
 	} else {
@@ -201,8 +270,28 @@ func goMarshal(closure *C.GClosure, return_value *C.GValue, n_param_values C.gui
 		if err != nil {
 			panic(err)
 		}
+		// return_value takes ownership of the contents of g, which
+		// must no longer be unset when g is collected.
 		(*return_value) = *g.Native()
+		runtime.SetFinalizer(g, nil)
+	}
+}
+
+// marshalParam() converts a signal argument to a Go value with
+// GoValue(), or else to a *Value holding a copy of the argument that
+// remains valid after the emission.
+func marshalParam(param *C.GValue) interface{} {
+	v := &Value{*param}
+	if val, err := v.GoValue(); err == nil {
+		return val
 	}
+	actual, _ := v.Type()
+	cp, err := ValueInit(actual)
+	if err != nil {
+		panic(err)
+	}
+	C.g_value_copy(param, cp.Native())
+	return cp
 }
 
 /*
@@ -252,6 +341,143 @@ func idleAdd(context *MainContext, f func() bool) (SourceHandle, error) {
 	return SourceHandle(cid), nil
 }
 
+// chanSource holds the state of a source created by ChanSource().
+type chanSource struct {
+	sync.Mutex
+	pending []reflect.Value
+	closed  bool
+	stopped bool
+	stop    chan struct{}
+	f       reflect.Value
+}
+
+var chanSources = struct {
+	sync.Mutex
+	m map[*C.GSource]*chanSource
+}{m: make(map[*C.GSource]*chanSource)}
+
+// ChanSource() attaches a source to the default main context which
+// receives values from the channel ch and calls f with each of them in
+// the main loop.  f must be a function taking a single argument to which
+// the channel's element type is assignable.  Values are received by a
+// separate goroutine, so ch may be sent to from any goroutine.  The
+// source is removed once ch is closed and every received value has been
+// delivered.  If the source is removed first, the goroutine stops
+// receiving from ch, and any values it has already received but not yet
+// delivered are dropped.
+func ChanSource(ch interface{}, f interface{}) (SourceHandle, error) {
+	return chanSourceAdd(nil, ch, f)
+}
+
+// chanSourceAdd() attaches a channel source to the provided main context.
+func chanSourceAdd(context *MainContext, ch interface{}, f interface{}) (SourceHandle, error) {
+	chv := reflect.ValueOf(ch)
+	if chv.Kind() != reflect.Chan || chv.Type().ChanDir()&reflect.RecvDir == 0 {
+		return 0, errors.New("ChanSource requires a receivable channel")
+	}
+	fv := reflect.ValueOf(f)
+	if fv.Kind() != reflect.Func || fv.Type().NumIn() != 1 ||
+		!chv.Type().Elem().AssignableTo(fv.Type().In(0)) {
+		return 0, fmt.Errorf("ChanSource requires a func(%s)", chv.Type().Elem())
+	}
+
+	c := C._g_chan_source_new()
+	if c == nil {
+		return 0, nilPtrErr
+	}
+	var ctx *C.GMainContext = nil
+	if context != nil {
+		ctx = (*C.GMainContext)(context.ptr)
+	}
+	cs := &chanSource{f: fv, stop: make(chan struct{})}
+	chanSources.Lock()
+	chanSources.m[c] = cs
+	chanSources.Unlock()
+	cid := C.g_source_attach(c, ctx)
+	C.g_source_unref(c)
+
+	// The receiving goroutine holds no reference to the source.  It
+	// only touches the source with cs locked and stops once
+	// goChanSourceFinalize() has marked cs as stopped, so it neither
+	// outlives the source nor receives values after it is removed.
+	go func() {
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: chv},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(cs.stop)},
+		}
+		for {
+			select {
+			case <-cs.stop:
+				return
+			default:
+			}
+			chosen, val, ok := reflect.Select(cases)
+			if chosen == 1 {
+				return
+			}
+			cs.Lock()
+			if cs.stopped {
+				cs.Unlock()
+				return
+			}
+			if ok {
+				cs.pending = append(cs.pending, val)
+			} else {
+				cs.closed = true
+			}
+			C.g_source_set_ready_time(c, 0)
+			cs.Unlock()
+			if !ok {
+				return
+			}
+		}
+	}()
+	return SourceHandle(cid), nil
+}
+
+// goChanSourceDispatch() is called by the GLib runtime when a channel
+// source has received values to deliver.
+//
+//export goChanSourceDispatch
+func goChanSourceDispatch(source *C.GSource) C.gboolean {
+	chanSources.Lock()
+	cs := chanSources.m[source]
+	chanSources.Unlock()
+	if cs == nil {
+		return gbool(false)
+	}
+
+	cs.Lock()
+	pending := cs.pending
+	cs.pending = nil
+	closed := cs.closed
+	cs.Unlock()
+
+	for _, val := range pending {
+		cs.f.Call([]reflect.Value{val})
+	}
+	return gbool(!closed)
+}
+
+// goChanSourceFinalize() is called by the GLib runtime when a channel
+// source is finalized, and removes its state from the internally-maintained
+// map.
+//
+//export goChanSourceFinalize
+func goChanSourceFinalize(source *C.GSource) {
+	chanSources.Lock()
+	cs := chanSources.m[source]
+	delete(chanSources.m, source)
+	chanSources.Unlock()
+	if cs == nil {
+		return
+	}
+	cs.Lock()
+	cs.stopped = true
+	close(cs.stop)
+	cs.Unlock()
+}
+
 /*
  * Main event loop
  */
@@ -264,6 +490,12 @@ func (v *MainContext) IdleAdd(f func() bool) (SourceHandle, error) {
 	return idleAdd(v, f)
 }
 
+// ChanSource() attaches a channel source to the main context.  See the
+// package-level ChanSource() for details.
+func (v *MainContext) ChanSource(ch interface{}, f interface{}) (SourceHandle, error) {
+	return chanSourceAdd(v, ch, f)
+}
+
 type MainLoop struct {
 	ptr unsafe.Pointer
 }
@@ -300,6 +532,90 @@ func (v *MainLoop) Context() *MainContext {
 	return &MainContext{unsafe.Pointer(C.g_main_loop_get_context(v.Native()))}
 }
 
+/*
+ * Doubly- and singly-linked lists
+ */
+
+// Transfer describes the ownership of a GList or GSList, and of the data
+// held by its elements, as given by the transfer annotation of the C
+// function returning it.
+type Transfer int
+
+const (
+	// The list and its elements are owned by the callee.
+	TRANSFER_NONE Transfer = iota
+	// The list is owned by the caller, but its elements are not.
+	TRANSFER_CONTAINER
+	// The list and its elements are owned by the caller.
+	TRANSFER_FULL
+)
+
+// ListToSlice() returns the data pointers of the elements of the GList
+// list, in order.  With TRANSFER_CONTAINER and TRANSFER_FULL, the list is
+// freed after conversion.  With TRANSFER_FULL, ownership of each
+// element's data also passes to the caller, which must arrange for it to
+// be freed.  ListToSlice() is exported for visibility to other gotk3
+// packages and shouldn't be used in application code.
+func ListToSlice(list unsafe.Pointer, transfer Transfer) []unsafe.Pointer {
+	var s []unsafe.Pointer
+	for l := (*C.GList)(list); l != nil; l = l.next {
+		s = append(s, unsafe.Pointer(l.data))
+	}
+	if transfer != TRANSFER_NONE {
+		C.g_list_free((*C.GList)(list))
+	}
+	return s
+}
+
+// SListToSlice() converts the GSList list to a slice, with the same
+// semantics as ListToSlice().  SListToSlice() is exported for visibility
+// to other gotk3 packages and shouldn't be used in application code.
+func SListToSlice(list unsafe.Pointer, transfer Transfer) []unsafe.Pointer {
+	var s []unsafe.Pointer
+	for l := (*C.GSList)(list); l != nil; l = l.next {
+		s = append(s, unsafe.Pointer(l.data))
+	}
+	if transfer != TRANSFER_NONE {
+		C.g_slist_free((*C.GSList)(list))
+	}
+	return s
+}
+
+// ListFromSlice() creates a new GList holding the pointers in data, in
+// order.  The list must be freed with ListFree() once it is no longer
+// needed, but the data it points to is not copied or freed.
+// ListFromSlice() is exported for visibility to other gotk3 packages and
+// shouldn't be used in application code.
+func ListFromSlice(data []unsafe.Pointer) unsafe.Pointer {
+	var list *C.GList
+	for i := len(data) - 1; i >= 0; i-- {
+		list = C.g_list_prepend(list, C.gpointer(data[i]))
+	}
+	return unsafe.Pointer(list)
+}
+
+// SListFromSlice() creates a new GSList holding the pointers in data, in
+// order.  The list must be freed with SListFree() once it is no longer
+// needed.  SListFromSlice() is exported for visibility to other gotk3
+// packages and shouldn't be used in application code.
+func SListFromSlice(data []unsafe.Pointer) unsafe.Pointer {
+	var list *C.GSList
+	for i := len(data) - 1; i >= 0; i-- {
+		list = C.g_slist_prepend(list, C.gpointer(data[i]))
+	}
+	return unsafe.Pointer(list)
+}
+
+// ListFree() is a wrapper around g_list_free().
+func ListFree(list unsafe.Pointer) {
+	C.g_list_free((*C.GList)(list))
+}
+
+// SListFree() is a wrapper around g_slist_free().
+func SListFree(list unsafe.Pointer) {
+	C.g_slist_free((*C.GSList)(list))
+}
+
 /*
  * Miscellaneous Utility Functions
  */
@@ -315,6 +631,81 @@ func GetUserSpecialDir(directory UserDirectory) (string, error) {
 	return C.GoString((*C.char)(c)), nil
 }
 
+// GetUserConfigDir() is a wrapper around g_get_user_config_dir().
+func GetUserConfigDir() string {
+	c := C.g_get_user_config_dir()
+	return C.GoString((*C.char)(c))
+}
+
+// GetUserDataDir() is a wrapper around g_get_user_data_dir().
+func GetUserDataDir() string {
+	c := C.g_get_user_data_dir()
+	return C.GoString((*C.char)(c))
+}
+
+// GetUserCacheDir() is a wrapper around g_get_user_cache_dir().
+func GetUserCacheDir() string {
+	c := C.g_get_user_cache_dir()
+	return C.GoString((*C.char)(c))
+}
+
+// GetUserRuntimeDir() is a wrapper around g_get_user_runtime_dir().
+func GetUserRuntimeDir() string {
+	c := C.g_get_user_runtime_dir()
+	return C.GoString((*C.char)(c))
+}
+
+// GetSystemDataDirs() is a wrapper around g_get_system_data_dirs().
+func GetSystemDataDirs() []string {
+	c := C.g_get_system_data_dirs()
+	return goStrv((**C.gchar)(unsafe.Pointer(c)))
+}
+
+// GetSystemConfigDirs() is a wrapper around g_get_system_config_dirs().
+func GetSystemConfigDirs() []string {
+	c := C.g_get_system_config_dirs()
+	return goStrv((**C.gchar)(unsafe.Pointer(c)))
+}
+
+// GetApplicationName() is a wrapper around g_get_application_name().  A
+// non-nil error is returned in the case that g_get_application_name()
+// returns NULL, which happens when neither the application name nor the
+// program name has been set.
+func GetApplicationName() (string, error) {
+	c := C.g_get_application_name()
+	if c == nil {
+		return "", nilPtrErr
+	}
+	return C.GoString((*C.char)(c)), nil
+}
+
+// SetApplicationName() is a wrapper around g_set_application_name().
+func SetApplicationName(name string) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_set_application_name((*C.gchar)(cstr))
+}
+
+// GetPrgname() is a wrapper around g_get_prgname().  A non-nil error is
+// returned in the case that g_get_prgname() returns NULL, which happens
+// when the program name has not been set.
+func GetPrgname() (string, error) {
+	c := C.g_get_prgname()
+	if c == nil {
+		return "", nilPtrErr
+	}
+	return C.GoString((*C.char)(c)), nil
+}
+
+// SetPrgname() is a wrapper around g_set_prgname().  GTK uses the program
+// name as the default window manager class of its windows, so it should
+// be set before gtk.Init() is called.
+func SetPrgname(name string) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_set_prgname((*C.gchar)(cstr))
+}
+
 /*
  * GObject
  */
@@ -561,10 +952,60 @@ func (v *Object) HandlerUnblock(handle SignalHandle) {
 
 // HandlerDisconnect() is a wrapper around g_signal_handler_disconnect().
 func (v *Object) HandlerDisconnect(handle SignalHandle) {
+	signals.Lock()
+	closure, ok := signals.m[handle]
+	delete(signals.m, handle)
+	signals.Unlock()
+	if ok {
+		C.g_closure_invalidate(closure)
+	}
 	C.g_signal_handler_disconnect(C.gpointer(v.ptr), C.gulong(handle))
-	C.g_closure_invalidate(signals[handle])
-	delete(closures.m, signals[handle])
-	delete(signals, handle)
+}
+
+// SignalChan() connects to the signal specified by the string signal and
+// forwards the arguments of each emission, converted to Go values, onto
+// the returned channel.  Arguments with no Go conversion, such as the
+// GdkEvent of "key-press-event", are forwarded as a *Value.  The emitting
+// object itself is not included in the forwarded arguments.  buf sets
+// the capacity of the channel; once it is full, emissions block the
+// emitting thread (usually the main loop) until a receiver catches up.
+//
+// Calling the returned cancel function stops forwarding.  The handler
+// is disconnected and the channel closed from an idle callback, so the
+// channel is never closed while an emission is in progress.  cancel may
+// safely be called from any goroutine and more than once.
+func SignalChan(obj IObject, signal string, buf int) (<-chan []interface{}, func()) {
+	var (
+		ch   = make(chan []interface{}, buf)
+		done = make(chan struct{})
+		once sync.Once
+		o    = obj.ToObject()
+	)
+	h := o.Connect(signal, func(args ...interface{}) {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if len(args) > 0 {
+			args = args[1:]
+		}
+		select {
+		case ch <- args:
+		case <-done:
+		}
+	})
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			IdleAdd(func() bool {
+				o.HandlerDisconnect(h)
+				close(ch)
+				return false
+			})
+		})
+	}
+	return ch, cancel
 }
 
 /*
@@ -718,6 +1159,13 @@ func GValue(v interface{}) (gvalue *Value, err error) {
 		}
 		val.SetString(v.(string))
 		return val, nil
+	case *Variant:
+		val, err := ValueInit(TYPE_VARIANT)
+		if err != nil {
+			return nil, err
+		}
+		val.SetVariant(v.(*Variant))
+		return val, nil
 	default:
 		if obj, ok := v.(*Object); ok {
 			val, err := ValueInit(TYPE_OBJECT)
@@ -761,7 +1209,7 @@ func GValue(v interface{}) (gvalue *Value, err error) {
 			if err != nil {
 				return nil, err
 			}
-			val.SetPointer(rval.Pointer())
+			val.SetPointer(uintptr(rval.Uint()))
 			return val, nil
 		}
 	}
@@ -784,6 +1232,12 @@ func (v *Value) GoValue() (interface{}, error) {
 	case TYPE_NONE:
 		return nil, nil
 	case TYPE_INTERFACE:
+		// Interfaces with a GObject prerequisite, such as GFile, hold
+		// objects and are returned as for TYPE_OBJECT.
+		if gobool(C._g_value_holds_object(v.Native())) {
+			c := C.g_value_get_object(v.Native())
+			return ObjectNew(unsafe.Pointer(c)), nil
+		}
 		return nil, errors.New("interface conversion not yet implemented")
 	case TYPE_CHAR:
 		c := C.g_value_get_schar(v.Native())
@@ -806,11 +1260,14 @@ func (v *Value) GoValue() (interface{}, error) {
 	case TYPE_ULONG, TYPE_UINT64: // is uint64 the best option for ulongs?
 		c := C.g_value_get_uint64(v.Native())
 		return uint64(c), nil
-	// TODO: enums and flags can probably just be returned as ints
+	// Enums and flags are returned as ints, which may be converted to
+	// the matching Go type by the caller.
 	case TYPE_ENUM:
-		return nil, errors.New("enum conversion not yet implemented")
+		c := C.g_value_get_enum(v.Native())
+		return int(c), nil
 	case TYPE_FLAGS:
-		return nil, errors.New("flag conversion not yet implemented")
+		c := C.g_value_get_flags(v.Native())
+		return int(c), nil
 	case TYPE_FLOAT:
 		c := C.g_value_get_float(v.Native())
 		return float32(c), nil
@@ -821,8 +1278,13 @@ func (v *Value) GoValue() (interface{}, error) {
 		c := C.g_value_get_string(v.Native())
 		return C.GoString((*C.char)(c)), nil
 	case TYPE_POINTER:
-		return unsafe.Pointer(v.Native()), nil
+		c := C.g_value_get_pointer(v.Native())
+		return unsafe.Pointer(c), nil
 	case TYPE_BOXED:
+		if actual == Type(C.g_strv_get_type()) {
+			c := C.g_value_get_boxed(v.Native())
+			return goStrv((**C.gchar)(unsafe.Pointer(c))), nil
+		}
 		return nil, errors.New("boxed conversion not yet implemented")
 	case TYPE_PARAM:
 		return nil, errors.New("param conversion not yet implemented")
@@ -832,7 +1294,11 @@ func (v *Value) GoValue() (interface{}, error) {
 		// this may require an additional cast()-like method for each module
 		return ObjectNew(unsafe.Pointer(c)), nil
 	case TYPE_VARIANT:
-		return nil, errors.New("variant conversion not yet implemented")
+		c := C.g_value_get_variant(v.Native())
+		if c == nil {
+			return (*Variant)(nil), nil
+		}
+		return VariantFromPtr(unsafe.Pointer(c), TRANSFER_NONE), nil
 	default:
 		fmt.Fprintln(os.Stderr, "type conversion not supported for unexpected type!")
 		for t := actual; t != 0; t = t.Parent() {
@@ -894,6 +1360,11 @@ func (v *Value) SetString(val string) {
 	C.g_value_set_string(v.Native(), (*C.gchar)(cstr))
 }
 
+// SetVariant() is a wrapper around g_value_set_variant().
+func (v *Value) SetVariant(val *Variant) {
+	C.g_value_set_variant(v.Native(), val.native())
+}
+
 // SetInstance() is a wrapper around g_value_set_instance().
 func (v *Value) SetInstance(instance uintptr) {
 	C.g_value_set_instance(v.Native(), C.gpointer(instance))
@@ -1089,7 +1560,8 @@ func VariantNew(val interface{}) (*Variant, error) {
 		c = C.g_variant_new_variant(t.ptr)
 	case []string:
 		length := len(t)
-		strv := make([]*C.gchar, length)
+		// NULL-terminated, so that &strv[0] is valid for an empty list.
+		strv := make([]*C.gchar, length+1)
 		for i, str := range t {
 			cstr := C.CString(str)
 			defer C.free(unsafe.Pointer(cstr))
@@ -1098,7 +1570,8 @@ func VariantNew(val interface{}) (*Variant, error) {
 		c = C.g_variant_new_strv((**C.gchar)(unsafe.Pointer(&strv[0])), C.gssize(length))
 	case []ObjectPath:
 		length := len(t)
-		strv := make([]*C.gchar, length)
+		// NULL-terminated, so that &strv[0] is valid for an empty list.
+		strv := make([]*C.gchar, length+1)
 		for i, str := range t {
 			cstr := C.CString(string(str))
 			defer C.free(unsafe.Pointer(cstr))
@@ -1131,9 +1604,24 @@ func VariantNew(val interface{}) (*Variant, error) {
 	default:
 		return nil, fmt.Errorf("unexpected variant type: %v", val)
 	}
+	return VariantFromPtr(unsafe.Pointer(c), TRANSFER_FULL), nil
+}
+
+// VariantFromPtr() wraps a pointer to a C GVariant, for use by packages
+// binding functions which return one.  If transfer is TRANSFER_NONE, a new
+// reference is taken; otherwise the caller's reference, which may be
+// floating, is adopted.  The returned Variant releases its reference when
+// it is garbage collected.
+func VariantFromPtr(p unsafe.Pointer, transfer Transfer) *Variant {
+	c := (*C.GVariant)(p)
+	if transfer == TRANSFER_NONE {
+		C.g_variant_ref_sink(c)
+	} else {
+		C.g_variant_take_ref(c)
+	}
 	v := &Variant{c}
 	runtime.SetFinalizer(v, (*Variant).Unref)
-	return v, nil
+	return v
 }
 
 func VariantNewMaybe(typ VariantType) *Variant {
@@ -1173,6 +1661,36 @@ func VariantDictEntry(key, value *Variant) *Variant {
 	return &Variant{C.g_variant_new_dict_entry(key.ptr, value.ptr)}
 }
 
+// Ptr() returns a pointer to the underlying GVariant, or nil if v is nil.
+func (v *Variant) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(v.native())
+}
+
+func (v *Variant) native() *C.GVariant {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// TypeString() is a wrapper around g_variant_get_type_string().
+func (v *Variant) TypeString() string {
+	return C.GoString((*C.char)(C.g_variant_get_type_string(v.ptr)))
+}
+
+// Equal() is a wrapper around g_variant_equal().
+func (v *Variant) Equal(other *Variant) bool {
+	return gobool(C.g_variant_equal(C.gconstpointer(v.ptr),
+		C.gconstpointer(other.ptr)))
+}
+
+// Print() is a wrapper around g_variant_print().
+func (v *Variant) Print(typeAnnotate bool) string {
+	c := C.g_variant_print(v.ptr, gbool(typeAnnotate))
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
 func (v *Variant) AsMaybe() *Variant {
 	return &Variant{C.g_variant_new_maybe(nil, v.ptr)}
 }
@@ -1231,6 +1749,13 @@ func (v *Variant) String() string {
 	return C.GoString((*C.char)(C.g_variant_get_string(v.ptr, nil)))
 }
 
+// Strv() is a wrapper around g_variant_get_strv().
+func (v *Variant) Strv() []string {
+	c := C.g_variant_get_strv(v.ptr, nil)
+	defer C.g_free(C.gpointer(c))
+	return goStrv(c)
+}
+
 func (v *Variant) Variant() *Variant {
 	return &Variant{C.g_variant_get_variant(v.ptr)}
 }
@@ -1251,6 +1776,654 @@ func (v *Variant) Unref() {
 	C.g_variant_unref(v.ptr)
 }
 
+/*
+ * Key-value file parser
+ */
+
+// KeyFileFlags is a representation of GLib's GKeyFileFlags.
+type KeyFileFlags int
+
+const (
+	KEY_FILE_NONE              KeyFileFlags = C.G_KEY_FILE_NONE
+	KEY_FILE_KEEP_COMMENTS                  = C.G_KEY_FILE_KEEP_COMMENTS
+	KEY_FILE_KEEP_TRANSLATIONS              = C.G_KEY_FILE_KEEP_TRANSLATIONS
+)
+
+// Groups and keys defined by the Desktop Entry Specification.
+const (
+	KEY_FILE_DESKTOP_GROUP                string = C.G_KEY_FILE_DESKTOP_GROUP
+	KEY_FILE_DESKTOP_KEY_TYPE                    = C.G_KEY_FILE_DESKTOP_KEY_TYPE
+	KEY_FILE_DESKTOP_KEY_VERSION                 = C.G_KEY_FILE_DESKTOP_KEY_VERSION
+	KEY_FILE_DESKTOP_KEY_NAME                    = C.G_KEY_FILE_DESKTOP_KEY_NAME
+	KEY_FILE_DESKTOP_KEY_GENERIC_NAME            = C.G_KEY_FILE_DESKTOP_KEY_GENERIC_NAME
+	KEY_FILE_DESKTOP_KEY_NO_DISPLAY              = C.G_KEY_FILE_DESKTOP_KEY_NO_DISPLAY
+	KEY_FILE_DESKTOP_KEY_COMMENT                 = C.G_KEY_FILE_DESKTOP_KEY_COMMENT
+	KEY_FILE_DESKTOP_KEY_ICON                    = C.G_KEY_FILE_DESKTOP_KEY_ICON
+	KEY_FILE_DESKTOP_KEY_HIDDEN                  = C.G_KEY_FILE_DESKTOP_KEY_HIDDEN
+	KEY_FILE_DESKTOP_KEY_TRY_EXEC                = C.G_KEY_FILE_DESKTOP_KEY_TRY_EXEC
+	KEY_FILE_DESKTOP_KEY_EXEC                    = C.G_KEY_FILE_DESKTOP_KEY_EXEC
+	KEY_FILE_DESKTOP_KEY_PATH                    = C.G_KEY_FILE_DESKTOP_KEY_PATH
+	KEY_FILE_DESKTOP_KEY_TERMINAL                = C.G_KEY_FILE_DESKTOP_KEY_TERMINAL
+	KEY_FILE_DESKTOP_KEY_MIME_TYPE               = C.G_KEY_FILE_DESKTOP_KEY_MIME_TYPE
+	KEY_FILE_DESKTOP_KEY_CATEGORIES              = C.G_KEY_FILE_DESKTOP_KEY_CATEGORIES
+	KEY_FILE_DESKTOP_KEY_STARTUP_NOTIFY          = C.G_KEY_FILE_DESKTOP_KEY_STARTUP_NOTIFY
+	KEY_FILE_DESKTOP_KEY_STARTUP_WM_CLASS        = C.G_KEY_FILE_DESKTOP_KEY_STARTUP_WM_CLASS
+	KEY_FILE_DESKTOP_KEY_URL                     = C.G_KEY_FILE_DESKTOP_KEY_URL
+	KEY_FILE_DESKTOP_KEY_ACTIONS                 = C.G_KEY_FILE_DESKTOP_KEY_ACTIONS
+	KEY_FILE_DESKTOP_TYPE_APPLICATION            = C.G_KEY_FILE_DESKTOP_TYPE_APPLICATION
+	KEY_FILE_DESKTOP_TYPE_LINK                   = C.G_KEY_FILE_DESKTOP_TYPE_LINK
+	KEY_FILE_DESKTOP_TYPE_DIRECTORY              = C.G_KEY_FILE_DESKTOP_TYPE_DIRECTORY
+)
+
+// KeyFile is a representation of GLib's GKeyFile.
+type KeyFile struct {
+	ptr *C.GKeyFile
+}
+
+// KeyFileNew() is a wrapper around g_key_file_new().
+func KeyFileNew() *KeyFile {
+	k := &KeyFile{C.g_key_file_new()}
+	runtime.SetFinalizer(k, (*KeyFile).unref)
+	return k
+}
+
+func (v *KeyFile) unref() {
+	C.g_key_file_unref(v.ptr)
+}
+
+// Native() returns a pointer to the underlying GKeyFile.
+func (v *KeyFile) Native() *C.GKeyFile {
+	if v == nil {
+		return nil
+	}
+	return v.ptr
+}
+
+// SetListSeparator() is a wrapper around g_key_file_set_list_separator().
+// The default separator is ';'.
+func (v *KeyFile) SetListSeparator(separator byte) {
+	C.g_key_file_set_list_separator(v.Native(), C.gchar(separator))
+}
+
+// LoadFromFile() is a wrapper around g_key_file_load_from_file().
+func (v *KeyFile) LoadFromFile(file string, flags KeyFileFlags) error {
+	cstr := C.CString(file)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_key_file_load_from_file(v.Native(), (*C.gchar)(cstr),
+		C.GKeyFileFlags(flags), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// LoadFromData() is a wrapper around g_key_file_load_from_data().
+func (v *KeyFile) LoadFromData(data string, flags KeyFileFlags) error {
+	cstr := C.CString(data)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_key_file_load_from_data(v.Native(), (*C.gchar)(cstr),
+		C.gsize(len(data)), C.GKeyFileFlags(flags), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// LoadFromDataDirs() is a wrapper around
+// g_key_file_load_from_data_dirs().  file is a path relative to the
+// user and system data directories, such as
+// "applications/org.gnome.gedit.desktop", and the full path of the file
+// that was loaded is returned.
+func (v *KeyFile) LoadFromDataDirs(file string, flags KeyFileFlags) (string, error) {
+	cstr := C.CString(file)
+	defer C.free(unsafe.Pointer(cstr))
+	var (
+		fullPath *C.gchar
+		err      *C.GError = nil
+	)
+	c := C.g_key_file_load_from_data_dirs(v.Native(), (*C.gchar)(cstr),
+		&fullPath, C.GKeyFileFlags(flags), &err)
+	if !gobool(c) {
+		return "", goError(err)
+	}
+	defer C.g_free(C.gpointer(fullPath))
+	return C.GoString((*C.char)(fullPath)), nil
+}
+
+// ToData() is a wrapper around g_key_file_to_data().
+func (v *KeyFile) ToData() (string, error) {
+	var (
+		length C.gsize
+		err    *C.GError = nil
+	)
+	c := C.g_key_file_to_data(v.Native(), &length, &err)
+	if c == nil {
+		return "", goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoStringN((*C.char)(c), C.int(length)), nil
+}
+
+// SaveToFile() is a wrapper around g_key_file_save_to_file().
+func (v *KeyFile) SaveToFile(filename string) error {
+	cstr := C.CString(filename)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.g_key_file_save_to_file(v.Native(), (*C.gchar)(cstr), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// GetStartGroup() is a wrapper around g_key_file_get_start_group().
+func (v *KeyFile) GetStartGroup() string {
+	c := C.g_key_file_get_start_group(v.Native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// GetGroups() is a wrapper around g_key_file_get_groups().
+func (v *KeyFile) GetGroups() []string {
+	c := C.g_key_file_get_groups(v.Native(), nil)
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// GetKeys() is a wrapper around g_key_file_get_keys().
+func (v *KeyFile) GetKeys(group string) ([]string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	var err *C.GError = nil
+	c := C.g_key_file_get_keys(v.Native(), (*C.gchar)(cgroup), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	defer C.g_strfreev(c)
+	return goStrv(c), nil
+}
+
+// HasGroup() is a wrapper around g_key_file_has_group().
+func (v *KeyFile) HasGroup(group string) bool {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	c := C.g_key_file_has_group(v.Native(), (*C.gchar)(cgroup))
+	return gobool(c)
+}
+
+// HasKey() is a wrapper around g_key_file_has_key().  A non-nil error is
+// returned if group does not exist.
+func (v *KeyFile) HasKey(group, key string) (bool, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_has_key(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if err != nil {
+		return false, goError(err)
+	}
+	return gobool(c), nil
+}
+
+// RemoveGroup() is a wrapper around g_key_file_remove_group().
+func (v *KeyFile) RemoveGroup(group string) error {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	var err *C.GError = nil
+	c := C.g_key_file_remove_group(v.Native(), (*C.gchar)(cgroup), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// RemoveKey() is a wrapper around g_key_file_remove_key().
+func (v *KeyFile) RemoveKey(group, key string) error {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_remove_key(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// GetValue() is a wrapper around g_key_file_get_value().  The raw value
+// is returned without unescaping.
+func (v *KeyFile) GetValue(group, key string) (string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_value(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if c == nil {
+		return "", goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), nil
+}
+
+// SetValue() is a wrapper around g_key_file_set_value().  value is
+// stored as is, without escaping.
+func (v *KeyFile) SetValue(group, key, value string) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	C.g_key_file_set_value(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), (*C.gchar)(cvalue))
+}
+
+// GetString() is a wrapper around g_key_file_get_string().
+func (v *KeyFile) GetString(group, key string) (string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_string(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if c == nil {
+		return "", goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), nil
+}
+
+// SetString() is a wrapper around g_key_file_set_string().
+func (v *KeyFile) SetString(group, key, str string) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_key_file_set_string(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), (*C.gchar)(cstr))
+}
+
+// GetLocaleString() is a wrapper around g_key_file_get_locale_string().
+// If locale is empty, the current locale is used.  The value for the
+// closest matching locale is returned, falling back to the untranslated
+// value.
+func (v *KeyFile) GetLocaleString(group, key, locale string) (string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clocale := cStringOrNil(locale)
+	defer C.free(unsafe.Pointer(clocale))
+	var err *C.GError = nil
+	c := C.g_key_file_get_locale_string(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), clocale, &err)
+	if c == nil {
+		return "", goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), nil
+}
+
+// SetLocaleString() is a wrapper around g_key_file_set_locale_string().
+func (v *KeyFile) SetLocaleString(group, key, locale, str string) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clocale := C.CString(locale)
+	defer C.free(unsafe.Pointer(clocale))
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_key_file_set_locale_string(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), (*C.gchar)(clocale), (*C.gchar)(cstr))
+}
+
+// GetBoolean() is a wrapper around g_key_file_get_boolean().
+func (v *KeyFile) GetBoolean(group, key string) (bool, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_boolean(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if err != nil {
+		return false, goError(err)
+	}
+	return gobool(c), nil
+}
+
+// SetBoolean() is a wrapper around g_key_file_set_boolean().
+func (v *KeyFile) SetBoolean(group, key string, value bool) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	C.g_key_file_set_boolean(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), gbool(value))
+}
+
+// GetInteger() is a wrapper around g_key_file_get_integer().
+func (v *KeyFile) GetInteger(group, key string) (int, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_integer(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if err != nil {
+		return 0, goError(err)
+	}
+	return int(c), nil
+}
+
+// SetInteger() is a wrapper around g_key_file_set_integer().
+func (v *KeyFile) SetInteger(group, key string, value int) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	C.g_key_file_set_integer(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), C.gint(value))
+}
+
+// GetInt64() is a wrapper around g_key_file_get_int64().
+func (v *KeyFile) GetInt64(group, key string) (int64, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_int64(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if err != nil {
+		return 0, goError(err)
+	}
+	return int64(c), nil
+}
+
+// SetInt64() is a wrapper around g_key_file_set_int64().
+func (v *KeyFile) SetInt64(group, key string, value int64) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	C.g_key_file_set_int64(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), C.gint64(value))
+}
+
+// GetDouble() is a wrapper around g_key_file_get_double().
+func (v *KeyFile) GetDouble(group, key string) (float64, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_double(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &err)
+	if err != nil {
+		return 0, goError(err)
+	}
+	return float64(c), nil
+}
+
+// SetDouble() is a wrapper around g_key_file_set_double().
+func (v *KeyFile) SetDouble(group, key string, value float64) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	C.g_key_file_set_double(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), C.gdouble(value))
+}
+
+// GetStringList() is a wrapper around g_key_file_get_string_list().
+func (v *KeyFile) GetStringList(group, key string) ([]string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_string_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	defer C.g_strfreev(c)
+	return goStrv(c), nil
+}
+
+// SetStringList() is a wrapper around g_key_file_set_string_list().
+func (v *KeyFile) SetStringList(group, key string, list []string) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clist := make([]*C.gchar, len(list)+1)
+	for i, s := range list {
+		clist[i] = (*C.gchar)(C.CString(s))
+		defer C.free(unsafe.Pointer(clist[i]))
+	}
+	C.g_key_file_set_string_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &clist[0], C.gsize(len(list)))
+}
+
+// GetLocaleStringList() is a wrapper around
+// g_key_file_get_locale_string_list().  locale is handled as for
+// GetLocaleString().
+func (v *KeyFile) GetLocaleStringList(group, key, locale string) ([]string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clocale := cStringOrNil(locale)
+	defer C.free(unsafe.Pointer(clocale))
+	var err *C.GError = nil
+	c := C.g_key_file_get_locale_string_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), clocale, nil, &err)
+	if c == nil {
+		return nil, goError(err)
+	}
+	defer C.g_strfreev(c)
+	return goStrv(c), nil
+}
+
+// SetLocaleStringList() is a wrapper around
+// g_key_file_set_locale_string_list().
+func (v *KeyFile) SetLocaleStringList(group, key, locale string, list []string) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clocale := C.CString(locale)
+	defer C.free(unsafe.Pointer(clocale))
+	clist := make([]*C.gchar, len(list)+1)
+	for i, s := range list {
+		clist[i] = (*C.gchar)(C.CString(s))
+		defer C.free(unsafe.Pointer(clist[i]))
+	}
+	C.g_key_file_set_locale_string_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), (*C.gchar)(clocale), &clist[0],
+		C.gsize(len(list)))
+}
+
+// GetBooleanList() is a wrapper around g_key_file_get_boolean_list().
+func (v *KeyFile) GetBooleanList(group, key string) ([]bool, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var (
+		length C.gsize
+		err    *C.GError = nil
+	)
+	c := C.g_key_file_get_boolean_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &length, &err)
+	if err != nil {
+		return nil, goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	list := make([]bool, length)
+	for i := range list {
+		p := (*C.gboolean)(unsafe.Pointer(uintptr(unsafe.Pointer(c)) + uintptr(i)*unsafe.Sizeof(*c)))
+		list[i] = gobool(*p)
+	}
+	return list, nil
+}
+
+// SetBooleanList() is a wrapper around g_key_file_set_boolean_list().
+func (v *KeyFile) SetBooleanList(group, key string, list []bool) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clist := make([]C.gboolean, len(list)+1)
+	for i, b := range list {
+		clist[i] = gbool(b)
+	}
+	C.g_key_file_set_boolean_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &clist[0], C.gsize(len(list)))
+}
+
+// GetIntegerList() is a wrapper around g_key_file_get_integer_list().
+func (v *KeyFile) GetIntegerList(group, key string) ([]int, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var (
+		length C.gsize
+		err    *C.GError = nil
+	)
+	c := C.g_key_file_get_integer_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &length, &err)
+	if err != nil {
+		return nil, goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	list := make([]int, length)
+	for i := range list {
+		p := (*C.gint)(unsafe.Pointer(uintptr(unsafe.Pointer(c)) + uintptr(i)*unsafe.Sizeof(*c)))
+		list[i] = int(*p)
+	}
+	return list, nil
+}
+
+// SetIntegerList() is a wrapper around g_key_file_set_integer_list().
+func (v *KeyFile) SetIntegerList(group, key string, list []int) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clist := make([]C.gint, len(list)+1)
+	for i, n := range list {
+		clist[i] = C.gint(n)
+	}
+	C.g_key_file_set_integer_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &clist[0], C.gsize(len(list)))
+}
+
+// GetDoubleList() is a wrapper around g_key_file_get_double_list().
+func (v *KeyFile) GetDoubleList(group, key string) ([]float64, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var (
+		length C.gsize
+		err    *C.GError = nil
+	)
+	c := C.g_key_file_get_double_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &length, &err)
+	if err != nil {
+		return nil, goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	list := make([]float64, length)
+	for i := range list {
+		p := (*C.gdouble)(unsafe.Pointer(uintptr(unsafe.Pointer(c)) + uintptr(i)*unsafe.Sizeof(*c)))
+		list[i] = float64(*p)
+	}
+	return list, nil
+}
+
+// SetDoubleList() is a wrapper around g_key_file_set_double_list().
+func (v *KeyFile) SetDoubleList(group, key string, list []float64) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	clist := make([]C.gdouble, len(list)+1)
+	for i, f := range list {
+		clist[i] = C.gdouble(f)
+	}
+	C.g_key_file_set_double_list(v.Native(), (*C.gchar)(cgroup),
+		(*C.gchar)(ckey), &clist[0], C.gsize(len(list)))
+}
+
+// GetComment() is a wrapper around g_key_file_get_comment().  If key is
+// empty, the comment above group is returned; if group is also empty,
+// the comment at the top of the file is returned.
+func (v *KeyFile) GetComment(group, key string) (string, error) {
+	cgroup := cStringOrNil(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := cStringOrNil(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_get_comment(v.Native(), cgroup, ckey, &err)
+	if err != nil {
+		return "", goError(err)
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c)), nil
+}
+
+// SetComment() is a wrapper around g_key_file_set_comment().  group and
+// key are interpreted as for GetComment().  Each line of comment is
+// written prefixed with '#'.
+func (v *KeyFile) SetComment(group, key, comment string) error {
+	cgroup := cStringOrNil(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := cStringOrNil(key)
+	defer C.free(unsafe.Pointer(ckey))
+	ccomment := C.CString(comment)
+	defer C.free(unsafe.Pointer(ccomment))
+	var err *C.GError = nil
+	c := C.g_key_file_set_comment(v.Native(), cgroup, ckey,
+		(*C.gchar)(ccomment), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// RemoveComment() is a wrapper around g_key_file_remove_comment().
+// group and key are interpreted as for GetComment().
+func (v *KeyFile) RemoveComment(group, key string) error {
+	cgroup := cStringOrNil(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := cStringOrNil(key)
+	defer C.free(unsafe.Pointer(ckey))
+	var err *C.GError = nil
+	c := C.g_key_file_remove_comment(v.Native(), cgroup, ckey, &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
 /*
  * Invalid type handling
  */
@@ -85,20 +85,56 @@ _g_value_fundamental(GType type)
 	return (G_TYPE_FUNDAMENTAL(type));
 }
 
+static gboolean
+_g_value_holds_object(GValue *val)
+{
+	return (G_VALUE_HOLDS_OBJECT(val));
+}
+
 /*
  * Closures
  */
 
 extern void goMarshal(GClosure *closure, GValue *return_value, guint n_param_values, GValue *param_values, gpointer invocation_hint, gpointer marshal_data);
+extern void removeClosure(gpointer data, GClosure *closure);
 
 static GClosure *
 _g_closure_new()
 {
 	GClosure *closure = g_closure_new_simple(sizeof(GClosure), NULL);
 	g_closure_set_marshal(closure, (GClosureMarshal)(goMarshal));
+	g_closure_add_finalize_notifier(closure, NULL, removeClosure);
 	return closure;
 }
 
+/*
+ * Channel sources
+ */
+
+extern gboolean goChanSourceDispatch(GSource *source);
+extern void goChanSourceFinalize(GSource *source);
+
+static gboolean
+_g_chan_source_dispatch(GSource *source, GSourceFunc callback,
+    gpointer user_data)
+{
+	g_source_set_ready_time(source, -1);
+	return (goChanSourceDispatch(source));
+}
+
+static GSourceFuncs _g_chan_source_funcs = {
+	NULL,
+	NULL,
+	_g_chan_source_dispatch,
+	goChanSourceFinalize
+};
+
+static GSource *
+_g_chan_source_new()
+{
+	return (g_source_new(&_g_chan_source_funcs, sizeof(GSource)));
+}
+
 /*
  * Variant types
  */
@@ -264,3 +300,9 @@ _g_variant_type_vardict()
 {
 	return (G_VARIANT_TYPE_VARDICT);
 }
+
+static gchar *
+error_get_message(GError *error)
+{
+	return error->message;
+}
@@ -0,0 +1,393 @@
+package glib
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+const testDesktopEntry = `# Generated for tests
+
+[Desktop Entry]
+Type=Application
+Name=Editor
+Name[de]=Bearbeiter
+Name[fr_FR]=Éditeur
+Keywords=text;edit;
+Keywords[de]=Text;Bearbeiten;
+Exec=editor %U
+Terminal=false
+X-Count=3
+X-Big=9000000000
+X-Ratio=0.5
+X-Flags=true;false;true;
+X-Sizes=16;32;48;
+
+[Desktop Action new]
+Name=New Window
+Exec=editor --new-window
+`
+
+func loadTestKeyFile(t *testing.T) *KeyFile {
+	kf := KeyFileNew()
+	err := kf.LoadFromData(testDesktopEntry,
+		KEY_FILE_KEEP_COMMENTS|KEY_FILE_KEEP_TRANSLATIONS)
+	if err != nil {
+		t.Fatal("Unable to load key file:", err)
+	}
+	return kf
+}
+
+func TestKeyFileRoundTrip(t *testing.T) {
+	kf := loadTestKeyFile(t)
+	if group := kf.GetStartGroup(); group != KEY_FILE_DESKTOP_GROUP {
+		t.Errorf("Expected start group %q, got %q", KEY_FILE_DESKTOP_GROUP, group)
+	}
+	groups := kf.GetGroups()
+	if !reflect.DeepEqual(groups, []string{KEY_FILE_DESKTOP_GROUP, "Desktop Action new"}) {
+		t.Errorf("Unexpected groups %q", groups)
+	}
+
+	data, err := kf.ToData()
+	if err != nil {
+		t.Fatal("Unable to convert key file to data:", err)
+	}
+	for _, line := range []string{"# Generated for tests", "Name[de]=Bearbeiter", "Exec=editor %U"} {
+		if !strings.Contains(data, line+"\n") {
+			t.Errorf("Data is missing %q:\n%s", line, data)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "editor.desktop")
+	if err := kf.SaveToFile(path); err != nil {
+		t.Fatal("Unable to save key file:", err)
+	}
+	reloaded := KeyFileNew()
+	if err := reloaded.LoadFromFile(path, KEY_FILE_KEEP_COMMENTS|KEY_FILE_KEEP_TRANSLATIONS); err != nil {
+		t.Fatal("Unable to reload key file:", err)
+	}
+	again, err := reloaded.ToData()
+	if err != nil {
+		t.Fatal("Unable to convert reloaded key file to data:", err)
+	}
+	if again != data {
+		t.Errorf("Round trip changed the data:\n%s\nbecame\n%s", data, again)
+	}
+	keys, err := reloaded.GetKeys("Desktop Action new")
+	if err != nil || !reflect.DeepEqual(keys, []string{"Name", "Exec"}) {
+		t.Errorf("Unexpected keys %q (%v)", keys, err)
+	}
+
+	if err := KeyFileNew().LoadFromData("[Desktop Entry\n", KEY_FILE_NONE); err == nil {
+		t.Error("Expected an error loading malformed data")
+	}
+}
+
+func TestKeyFileLocaleString(t *testing.T) {
+	kf := loadTestKeyFile(t)
+	for _, tc := range []struct{ locale, want string }{
+		{"de_DE", "Bearbeiter"},
+		{"de", "Bearbeiter"},
+		{"fr_FR.UTF-8", "Éditeur"},
+		{"fr", "Editor"},
+		{"it_IT", "Editor"},
+	} {
+		got, err := kf.GetLocaleString(KEY_FILE_DESKTOP_GROUP, KEY_FILE_DESKTOP_KEY_NAME, tc.locale)
+		if err != nil || got != tc.want {
+			t.Errorf("Locale %s: expected %q, got %q (%v)", tc.locale, tc.want, got, err)
+		}
+	}
+
+	list, err := kf.GetLocaleStringList(KEY_FILE_DESKTOP_GROUP, "Keywords", "de_DE")
+	if err != nil || !reflect.DeepEqual(list, []string{"Text", "Bearbeiten"}) {
+		t.Errorf("Unexpected keywords %q (%v)", list, err)
+	}
+
+	kf.SetLocaleString(KEY_FILE_DESKTOP_GROUP, KEY_FILE_DESKTOP_KEY_NAME, "it", "Redattore")
+	if got, _ := kf.GetLocaleString(KEY_FILE_DESKTOP_GROUP, KEY_FILE_DESKTOP_KEY_NAME, "it_IT"); got != "Redattore" {
+		t.Errorf("Expected %q after SetLocaleString(), got %q", "Redattore", got)
+	}
+	if got, _ := kf.GetString(KEY_FILE_DESKTOP_GROUP, KEY_FILE_DESKTOP_KEY_NAME); got != "Editor" {
+		t.Errorf("Expected untranslated %q, got %q", "Editor", got)
+	}
+}
+
+func TestKeyFileTypedValues(t *testing.T) {
+	kf := loadTestKeyFile(t)
+	g := KEY_FILE_DESKTOP_GROUP
+
+	if b, err := kf.GetBoolean(g, KEY_FILE_DESKTOP_KEY_TERMINAL); err != nil || b {
+		t.Errorf("Expected false, got %v (%v)", b, err)
+	}
+	if n, err := kf.GetInteger(g, "X-Count"); err != nil || n != 3 {
+		t.Errorf("Expected 3, got %d (%v)", n, err)
+	}
+	if n, err := kf.GetInt64(g, "X-Big"); err != nil || n != 9000000000 {
+		t.Errorf("Expected 9000000000, got %d (%v)", n, err)
+	}
+	if f, err := kf.GetDouble(g, "X-Ratio"); err != nil || f != 0.5 {
+		t.Errorf("Expected 0.5, got %v (%v)", f, err)
+	}
+	if v, err := kf.GetValue(g, KEY_FILE_DESKTOP_KEY_EXEC); err != nil || v != "editor %U" {
+		t.Errorf("Expected %q, got %q (%v)", "editor %U", v, err)
+	}
+
+	if _, err := kf.GetInteger(g, KEY_FILE_DESKTOP_KEY_NAME); err == nil {
+		t.Error("Expected an error reading a string as an integer")
+	}
+	if _, err := kf.GetBoolean(g, "X-Count"); err == nil {
+		t.Error("Expected an error reading an integer as a boolean")
+	}
+	if _, err := kf.GetString(g, "X-Missing"); err == nil {
+		t.Error("Expected an error reading a missing key")
+	}
+	if _, err := kf.GetString("Missing Group", KEY_FILE_DESKTOP_KEY_NAME); err == nil {
+		t.Error("Expected an error reading from a missing group")
+	}
+	if _, err := kf.HasKey("Missing Group", KEY_FILE_DESKTOP_KEY_NAME); err == nil {
+		t.Error("Expected an error checking a key in a missing group")
+	}
+
+	kf.SetBoolean(g, KEY_FILE_DESKTOP_KEY_TERMINAL, true)
+	kf.SetInteger(g, "X-Count", -7)
+	kf.SetInt64(g, "X-Big", -9000000000)
+	kf.SetDouble(g, "X-Ratio", 1.25)
+	kf.SetString(g, "X-Text", "line one\nline two")
+	if b, _ := kf.GetBoolean(g, KEY_FILE_DESKTOP_KEY_TERMINAL); !b {
+		t.Error("SetBoolean() did not take effect")
+	}
+	if n, _ := kf.GetInteger(g, "X-Count"); n != -7 {
+		t.Errorf("Expected -7, got %d", n)
+	}
+	if n, _ := kf.GetInt64(g, "X-Big"); n != -9000000000 {
+		t.Errorf("Expected -9000000000, got %d", n)
+	}
+	if f, _ := kf.GetDouble(g, "X-Ratio"); f != 1.25 {
+		t.Errorf("Expected 1.25, got %v", f)
+	}
+	if s, _ := kf.GetString(g, "X-Text"); s != "line one\nline two" {
+		t.Errorf("Expected an escaped newline to round trip, got %q", s)
+	}
+	if v, _ := kf.GetValue(g, "X-Text"); v != `line one\nline two` {
+		t.Errorf("Expected the raw value to be escaped, got %q", v)
+	}
+
+	if err := kf.RemoveKey(g, "X-Text"); err != nil {
+		t.Error("Unable to remove key:", err)
+	}
+	if ok, err := kf.HasKey(g, "X-Text"); err != nil || ok {
+		t.Errorf("Expected key to be removed, got %v (%v)", ok, err)
+	}
+	if err := kf.RemoveGroup("Desktop Action new"); err != nil {
+		t.Error("Unable to remove group:", err)
+	}
+	if kf.HasGroup("Desktop Action new") {
+		t.Error("Expected group to be removed")
+	}
+}
+
+func TestKeyFileLists(t *testing.T) {
+	kf := loadTestKeyFile(t)
+	g := KEY_FILE_DESKTOP_GROUP
+
+	if l, err := kf.GetStringList(g, "Keywords"); err != nil || !reflect.DeepEqual(l, []string{"text", "edit"}) {
+		t.Errorf("Unexpected string list %q (%v)", l, err)
+	}
+	if l, err := kf.GetBooleanList(g, "X-Flags"); err != nil || !reflect.DeepEqual(l, []bool{true, false, true}) {
+		t.Errorf("Unexpected boolean list %v (%v)", l, err)
+	}
+	if l, err := kf.GetIntegerList(g, "X-Sizes"); err != nil || !reflect.DeepEqual(l, []int{16, 32, 48}) {
+		t.Errorf("Unexpected integer list %v (%v)", l, err)
+	}
+	if _, err := kf.GetIntegerList(g, "Keywords"); err == nil {
+		t.Error("Expected an error reading strings as an integer list")
+	}
+	if _, err := kf.GetStringList(g, "X-Missing"); err == nil {
+		t.Error("Expected an error reading a missing list")
+	}
+
+	kf.SetStringList(g, "X-Strings", []string{"a", "b;c"})
+	kf.SetBooleanList(g, "X-Bools", []bool{false, true})
+	kf.SetIntegerList(g, "X-Ints", []int{-1, 0, 1})
+	kf.SetDoubleList(g, "X-Doubles", []float64{0.25, 2})
+	kf.SetLocaleStringList(g, "Keywords", "it", []string{"testo"})
+	if l, _ := kf.GetStringList(g, "X-Strings"); !reflect.DeepEqual(l, []string{"a", "b;c"}) {
+		t.Errorf("Expected an escaped separator to round trip, got %q", l)
+	}
+	if l, _ := kf.GetBooleanList(g, "X-Bools"); !reflect.DeepEqual(l, []bool{false, true}) {
+		t.Errorf("Unexpected boolean list %v", l)
+	}
+	if l, _ := kf.GetIntegerList(g, "X-Ints"); !reflect.DeepEqual(l, []int{-1, 0, 1}) {
+		t.Errorf("Unexpected integer list %v", l)
+	}
+	if l, _ := kf.GetDoubleList(g, "X-Doubles"); !reflect.DeepEqual(l, []float64{0.25, 2}) {
+		t.Errorf("Unexpected double list %v", l)
+	}
+	if l, _ := kf.GetLocaleStringList(g, "Keywords", "it_IT"); !reflect.DeepEqual(l, []string{"testo"}) {
+		t.Errorf("Unexpected locale string list %q", l)
+	}
+
+	// Empty lists are written as empty values and read back as empty
+	// slices without an error.
+	kf.SetStringList(g, "X-Strings", nil)
+	kf.SetBooleanList(g, "X-Bools", []bool{})
+	kf.SetIntegerList(g, "X-Ints", nil)
+	kf.SetDoubleList(g, "X-Doubles", nil)
+	for _, key := range []string{"X-Strings", "X-Bools", "X-Ints", "X-Doubles"} {
+		if v, err := kf.GetValue(g, key); err != nil || v != "" {
+			t.Errorf("%s: expected an empty value, got %q (%v)", key, v, err)
+		}
+	}
+	if l, err := kf.GetStringList(g, "X-Strings"); err != nil || len(l) != 0 {
+		t.Errorf("Expected an empty string list, got %q (%v)", l, err)
+	}
+	if l, err := kf.GetBooleanList(g, "X-Bools"); err != nil || len(l) != 0 {
+		t.Errorf("Expected an empty boolean list, got %v (%v)", l, err)
+	}
+	if l, err := kf.GetIntegerList(g, "X-Ints"); err != nil || len(l) != 0 {
+		t.Errorf("Expected an empty integer list, got %v (%v)", l, err)
+	}
+	if l, err := kf.GetDoubleList(g, "X-Doubles"); err != nil || len(l) != 0 {
+		t.Errorf("Expected an empty double list, got %v (%v)", l, err)
+	}
+}
+
+func TestKeyFileComments(t *testing.T) {
+	kf := loadTestKeyFile(t)
+	top, err := kf.GetComment("", "")
+	if err != nil || strings.TrimSpace(top) != "Generated for tests" {
+		t.Errorf("Unexpected top comment %q (%v)", top, err)
+	}
+
+	const action = "Desktop Action new"
+	if err := kf.SetComment("", "", " Edited"); err != nil {
+		t.Error("Unable to set top comment:", err)
+	}
+	if err := kf.SetComment(action, "", " New window action"); err != nil {
+		t.Error("Unable to set group comment:", err)
+	}
+	if err := kf.SetComment(action, KEY_FILE_DESKTOP_KEY_EXEC, " Opens a window"); err != nil {
+		t.Error("Unable to set key comment:", err)
+	}
+	if err := kf.SetComment(action, "X-Missing", "x"); err == nil {
+		t.Error("Expected an error commenting a missing key")
+	}
+	for _, tc := range []struct{ group, key, want string }{
+		{"", "", "Edited"},
+		{action, "", "New window action"},
+		{action, KEY_FILE_DESKTOP_KEY_EXEC, "Opens a window"},
+	} {
+		got, err := kf.GetComment(tc.group, tc.key)
+		if err != nil || strings.TrimSpace(got) != tc.want {
+			t.Errorf("Comment for %q/%q: expected %q, got %q (%v)",
+				tc.group, tc.key, tc.want, got, err)
+		}
+	}
+
+	// Comments are written out and survive a reload.
+	data, _ := kf.ToData()
+	for _, line := range []string{"# Edited", "# New window action", "# Opens a window\nExec=editor --new-window"} {
+		if !strings.Contains(data, line) {
+			t.Errorf("Data is missing %q:\n%s", line, data)
+		}
+	}
+	reloaded := KeyFileNew()
+	if err := reloaded.LoadFromData(data, KEY_FILE_KEEP_COMMENTS); err != nil {
+		t.Fatal("Unable to reload key file:", err)
+	}
+	if got, _ := reloaded.GetComment(action, KEY_FILE_DESKTOP_KEY_EXEC); strings.TrimSpace(got) != "Opens a window" {
+		t.Errorf("Unexpected key comment after reload %q", got)
+	}
+
+	if err := kf.RemoveComment(action, KEY_FILE_DESKTOP_KEY_EXEC); err != nil {
+		t.Error("Unable to remove comment:", err)
+	}
+	if got, err := kf.GetComment(action, KEY_FILE_DESKTOP_KEY_EXEC); err != nil || got != "" {
+		t.Errorf("Expected no comment after removal, got %q (%v)", got, err)
+	}
+}
+
+// testListData returns n variants holding 0 through n-1, and pointers to
+// them for use as list data.
+func testListData(t *testing.T, n int) ([]*Variant, []unsafe.Pointer) {
+	vs := make([]*Variant, n)
+	ptrs := make([]unsafe.Pointer, n)
+	for i := range vs {
+		v, err := VariantNew(int32(i))
+		if err != nil {
+			t.Fatal("Unable to create variant:", err)
+		}
+		vs[i], ptrs[i] = v, v.Ptr()
+	}
+	return vs, ptrs
+}
+
+func TestListToSlice(t *testing.T) {
+	vs, ptrs := testListData(t, 3)
+
+	// With TRANSFER_NONE the list is left alone, so it may be converted
+	// again before being freed.
+	list := ListFromSlice(ptrs)
+	if got := ListToSlice(list, TRANSFER_NONE); !reflect.DeepEqual(got, ptrs) {
+		t.Errorf("Expected %v, got %v", ptrs, got)
+	}
+	if got := ListToSlice(list, TRANSFER_CONTAINER); !reflect.DeepEqual(got, ptrs) {
+		t.Errorf("Expected %v, got %v", ptrs, got)
+	}
+
+	// With TRANSFER_FULL the list's references to its data pass to the
+	// caller.
+	for _, v := range vs {
+		v.RefSink()
+	}
+	list = ListFromSlice(ptrs)
+	for i, p := range ListToSlice(list, TRANSFER_FULL) {
+		if n := VariantFromPtr(p, TRANSFER_FULL).Int32(); n != int32(i) {
+			t.Errorf("Element %d holds %d", i, n)
+		}
+	}
+
+	if list := ListFromSlice(nil); list != nil {
+		t.Error("Expected a nil list for an empty slice")
+		ListFree(list)
+	}
+	for _, transfer := range []Transfer{TRANSFER_NONE, TRANSFER_FULL} {
+		if got := ListToSlice(nil, transfer); len(got) != 0 {
+			t.Errorf("Expected no elements in an empty list, got %v", got)
+		}
+	}
+}
+
+func TestSListToSlice(t *testing.T) {
+	vs, ptrs := testListData(t, 3)
+
+	list := SListFromSlice(ptrs)
+	if got := SListToSlice(list, TRANSFER_NONE); !reflect.DeepEqual(got, ptrs) {
+		t.Errorf("Expected %v, got %v", ptrs, got)
+	}
+	if got := SListToSlice(list, TRANSFER_CONTAINER); !reflect.DeepEqual(got, ptrs) {
+		t.Errorf("Expected %v, got %v", ptrs, got)
+	}
+
+	for _, v := range vs {
+		v.RefSink()
+	}
+	list = SListFromSlice(ptrs)
+	for i, p := range SListToSlice(list, TRANSFER_FULL) {
+		if n := VariantFromPtr(p, TRANSFER_FULL).Int32(); n != int32(i) {
+			t.Errorf("Element %d holds %d", i, n)
+		}
+	}
+
+	if list := SListFromSlice(nil); list != nil {
+		t.Error("Expected a nil list for an empty slice")
+		SListFree(list)
+	}
+	for _, transfer := range []Transfer{TRANSFER_NONE, TRANSFER_FULL} {
+		if got := SListToSlice(nil, transfer); len(got) != 0 {
+			t.Errorf("Expected no elements in an empty list, got %v", got)
+		}
+	}
+}
@@ -17,7 +17,7 @@
  */
 
 /*
-Go bindings for GTK+ 3.  Supports version 3.8 and later.
+Go bindings for GTK+ 3.  Supports version 3.18 and later.
 
 Functions use the same names as the native C function calls, but use
 CamelCase.  In cases where native GTK uses pointers to values to
@@ -56,6 +56,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/dradtke/gotk3/gdk"
+	"github.com/dradtke/gotk3/gio"
 	"github.com/dradtke/gotk3/glib"
 	"os"
 	"runtime"
@@ -80,6 +81,72 @@ func gobool(b C.gboolean) bool {
 	return false
 }
 
+// goStrv() converts a NULL-terminated array of C strings to a Go slice.
+func goStrv(c **C.gchar) []string {
+	var strs []string
+	if c == nil {
+		return strs
+	}
+	for p := c; *p != nil; p = (**C.gchar)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + unsafe.Sizeof(*p))) {
+		strs = append(strs, C.GoString((*C.char)(*p)))
+	}
+	return strs
+}
+
+// menuModel() returns a pointer to the GMenuModel underlying model, or
+// nil if model is nil.
+func menuModel(model gio.IMenuModel) *C.GMenuModel {
+	if model == nil {
+		return nil
+	}
+	return (*C.GMenuModel)(model.ToObject().Ptr())
+}
+
+// gicon() returns the GIcon underlying icon, or nil if icon is nil.
+func gicon(icon gio.IIcon) *C.GIcon {
+	if icon == nil {
+		return nil
+	}
+	return (*C.GIcon)(icon.ToObject().Ptr())
+}
+
+// listModel() returns the GListModel underlying model, or nil if model is
+// nil.
+func listModel(model gio.IListModel) *C.GListModel {
+	if model == nil {
+		return nil
+	}
+	return (*C.GListModel)(model.ToObject().Ptr())
+}
+
+// createWidgetClosure() returns a closure for _gtk_create_widget_closure()
+// which calls f with each item of a bound model.
+func createWidgetClosure(f func(item *glib.Object) IWidget) *C.GClosure {
+	if f == nil {
+		return nil
+	}
+	closure := glib.ClosureNew(func(item *glib.Object) *glib.Object {
+		item.Ref()
+		runtime.SetFinalizer(item, (*glib.Object).Unref)
+		// Returning the widget's own Object keeps its Go reference alive
+		// until the return value takes one of its own.
+		w := f(item)
+		if obj, ok := w.(glib.IObject); ok {
+			return obj.ToObject()
+		}
+		return glib.ObjectNew(unsafe.Pointer(w.toWidget()))
+	})
+	return (*C.GClosure)(unsafe.Pointer(closure))
+}
+
+// goIcon() wraps a GIcon owned by GTK, adding a reference for Go.
+func goIcon(c *C.GIcon) *gio.Icon {
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &gio.Icon{Object: obj}
+}
+
 // Wrapper function for TestBoolConvs since cgo can't be used with
 // testing package
 func testBoolConvs() error {
@@ -116,6 +183,17 @@ const (
 	ALIGN_CENTER       = C.GTK_ALIGN_CENTER
 )
 
+// ApplicationInhibitFlags is a representation of GTK's
+// GtkApplicationInhibitFlags.
+type ApplicationInhibitFlags int
+
+const (
+	APPLICATION_INHIBIT_LOGOUT  ApplicationInhibitFlags = C.GTK_APPLICATION_INHIBIT_LOGOUT
+	APPLICATION_INHIBIT_SWITCH                          = C.GTK_APPLICATION_INHIBIT_SWITCH
+	APPLICATION_INHIBIT_SUSPEND                         = C.GTK_APPLICATION_INHIBIT_SUSPEND
+	APPLICATION_INHIBIT_IDLE                            = C.GTK_APPLICATION_INHIBIT_IDLE
+)
+
 // ButtonsType is a representation of GTK's GtkButtonsType.
 type ButtonsType int
 
@@ -273,6 +351,16 @@ const (
 	RESPONSE_HELP                      = C.GTK_RESPONSE_HELP
 )
 
+// SelectionMode is a representation of GTK's GtkSelectionMode.
+type SelectionMode int
+
+const (
+	SELECTION_NONE     SelectionMode = C.GTK_SELECTION_NONE
+	SELECTION_SINGLE                 = C.GTK_SELECTION_SINGLE
+	SELECTION_BROWSE                 = C.GTK_SELECTION_BROWSE
+	SELECTION_MULTIPLE               = C.GTK_SELECTION_MULTIPLE
+)
+
 // Stock is a special type that does not have an equivalent type in
 // GTK.  It is the type used as a parameter anytime an identifier for
 // stock icons are needed.  A Stock must be type converted to string when
@@ -449,6 +537,35 @@ func Init(args *[]string) {
 	}
 }
 
+// InitCheck() is a wrapper around gtk_init_check() and works like Init(),
+// except that a non-nil error is returned instead of terminating the
+// program when GTK cannot be initialized, such as when no display is
+// available.
+func InitCheck(args *[]string) error {
+	var c C.gboolean
+	if args != nil {
+		argc := C.int(len(*args))
+		argv := make([]*C.char, argc)
+		for i, arg := range *args {
+			argv[i] = C.CString(arg)
+		}
+		c = C.gtk_init_check((*C.int)(unsafe.Pointer(&argc)),
+			(***C.char)(unsafe.Pointer(&argv)))
+		unhandled := make([]string, argc)
+		for i := 0; i < int(argc); i++ {
+			unhandled[i] = C.GoString(argv[i])
+			C.free(unsafe.Pointer(argv[i]))
+		}
+		*args = unhandled
+	} else {
+		c = C.gtk_init_check(nil, nil)
+	}
+	if !gobool(c) {
+		return errors.New("unable to initialize GTK")
+	}
+	return nil
+}
+
 // Main() is a wrapper around gtk_main() and runs the GTK main loop,
 // blocking until MainQuit() is called.
 func Main() {
@@ -469,6 +586,39 @@ func MainIteration() bool {
 	return gobool(C.gtk_main_iteration())
 }
 
+// MainIterationDo() is a wrapper around gtk_main_iteration_do().
+func MainIterationDo(blocking bool) bool {
+	return gobool(C.gtk_main_iteration_do(gbool(blocking)))
+}
+
+// EventsPending() is a wrapper around gtk_events_pending().
+func EventsPending() bool {
+	return gobool(C.gtk_events_pending())
+}
+
+/*
+ * Filesystem utilities
+ */
+
+// ShowURI() is a wrapper around gtk_show_uri().  If screen is nil, the
+// default screen is used.  timestamp is typically the time of the event
+// which triggered the launch, or gdk.CURRENT_TIME.
+func ShowURI(screen *gdk.Screen, uri string, timestamp uint32) error {
+	var cscreen *C.GdkScreen
+	if screen != nil {
+		cscreen = (*C.GdkScreen)(unsafe.Pointer(screen.Native()))
+	}
+	cstr := C.CString(uri)
+	defer C.free(unsafe.Pointer(cstr))
+	var err *C.GError = nil
+	c := C.gtk_show_uri(cscreen, (*C.gchar)(cstr), C.guint32(timestamp), &err)
+	if !gobool(c) {
+		defer C.g_error_free(err)
+		return errors.New(C.GoString((*C.char)(C.error_get_message(err))))
+	}
+	return nil
+}
+
 /*
  * GtkAdjustment
  */
@@ -499,6 +649,244 @@ func wrapAdjustment(obj *glib.Object) Adjustment {
 	return Adjustment{glib.InitiallyUnowned{*obj}}
 }
 
+/*
+ * GtkApplication
+ */
+
+// Application is a representation of GTK's GtkApplication.
+type Application struct {
+	gio.Application
+}
+
+var applicationType = glib.Type(C.gtk_application_get_type())
+
+func GetApplicationType() glib.Type {
+	return applicationType
+}
+
+// Native() returns a pointer to the underlying GtkApplication.
+func (v *Application) Native() *C.GtkApplication {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	if warn := v.Typecheck(applicationType); warn != nil {
+		fmt.Fprintln(os.Stderr, warn)
+	}
+	return (*C.GtkApplication)(v.Ptr())
+}
+
+func wrapApplication(obj *glib.Object) (a Application) {
+	a.Application = gio.Application{
+		Object:      obj,
+		ActionGroup: gio.ActionGroup{Object: obj},
+		ActionMap:   gio.ActionMap{Object: obj},
+	}
+	return
+}
+
+// ApplicationNew() is a wrapper around gtk_application_new().  GTK is
+// initialized when the application is started, so Init() need not be
+// called.
+func ApplicationNew(id string, flags gio.ApplicationFlags) (*Application, error) {
+	cstr := C.CString(id)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.gtk_application_new((*C.gchar)(cstr), C.GApplicationFlags(flags))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := wrapApplication(obj)
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &a, nil
+}
+
+// AddWindow() is a wrapper around gtk_application_add_window().
+func (v *Application) AddWindow(window IWindow) {
+	C.gtk_application_add_window(v.Native(), window.toWindow())
+}
+
+// RemoveWindow() is a wrapper around gtk_application_remove_window().
+func (v *Application) RemoveWindow(window IWindow) {
+	C.gtk_application_remove_window(v.Native(), window.toWindow())
+}
+
+// GetWindows() is a wrapper around gtk_application_get_windows().  Each
+// window is returned as the Go type matching its class.
+func (v *Application) GetWindows() []glib.IObject {
+	c := C.gtk_application_get_windows(v.Native())
+	return widgetList(unsafe.Pointer(c), glib.TRANSFER_NONE)
+}
+
+// GetWindowById() is a wrapper around gtk_application_get_window_by_id().
+func (v *Application) GetWindowById(id uint) (*Window, error) {
+	c := C.gtk_application_get_window_by_id(v.Native(), C.guint(id))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	w := wrapWindow(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &w, nil
+}
+
+// GetActiveWindow() is a wrapper around gtk_application_get_active_window().
+func (v *Application) GetActiveWindow() (*Window, error) {
+	c := C.gtk_application_get_active_window(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	w := wrapWindow(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &w, nil
+}
+
+// Inhibit() is a wrapper around gtk_application_inhibit().  window may
+// be nil.  The returned cookie is passed to Uninhibit() to remove the
+// inhibitor, and is 0 if the request failed.
+func (v *Application) Inhibit(window IWindow, flags ApplicationInhibitFlags, reason string) uint {
+	var w *C.GtkWindow = nil
+	if window != nil {
+		w = window.toWindow()
+	}
+	cstr := C.CString(reason)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.gtk_application_inhibit(v.Native(), w,
+		C.GtkApplicationInhibitFlags(flags), (*C.gchar)(cstr))
+	return uint(c)
+}
+
+// Uninhibit() is a wrapper around gtk_application_uninhibit().
+func (v *Application) Uninhibit(cookie uint) {
+	C.gtk_application_uninhibit(v.Native(), C.guint(cookie))
+}
+
+// IsInhibited() is a wrapper around gtk_application_is_inhibited().
+func (v *Application) IsInhibited(flags ApplicationInhibitFlags) bool {
+	c := C.gtk_application_is_inhibited(v.Native(),
+		C.GtkApplicationInhibitFlags(flags))
+	return gobool(c)
+}
+
+// SetAccelsForAction() is a wrapper around
+// gtk_application_set_accels_for_action().  Accelerators are given in the
+// format understood by gtk_accelerator_parse(), such as "<Control>q".
+func (v *Application) SetAccelsForAction(detailedActionName string, accels []string) {
+	cstr := C.CString(detailedActionName)
+	defer C.free(unsafe.Pointer(cstr))
+	caccels := make([]*C.gchar, len(accels)+1)
+	for i, accel := range accels {
+		caccels[i] = (*C.gchar)(C.CString(accel))
+		defer C.free(unsafe.Pointer(caccels[i]))
+	}
+	C.gtk_application_set_accels_for_action(v.Native(), (*C.gchar)(cstr),
+		(**C.gchar)(unsafe.Pointer(&caccels[0])))
+}
+
+// GetAccelsForAction() is a wrapper around
+// gtk_application_get_accels_for_action().
+func (v *Application) GetAccelsForAction(detailedActionName string) []string {
+	cstr := C.CString(detailedActionName)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.gtk_application_get_accels_for_action(v.Native(),
+		(*C.gchar)(cstr))
+	defer C.g_strfreev(c)
+	return goStrv(c)
+}
+
+// SetAppMenu() is a wrapper around gtk_application_set_app_menu().
+func (v *Application) SetAppMenu(appMenu gio.IMenuModel) {
+	C.gtk_application_set_app_menu(v.Native(), menuModel(appMenu))
+}
+
+// SetMenubar() is a wrapper around gtk_application_set_menubar().
+func (v *Application) SetMenubar(menubar gio.IMenuModel) {
+	C.gtk_application_set_menubar(v.Native(), menuModel(menubar))
+}
+
+// GetMenubar() is a wrapper around gtk_application_get_menubar().
+func (v *Application) GetMenubar() (*gio.MenuModel, error) {
+	c := C.gtk_application_get_menubar(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := &gio.MenuModel{Object: obj}
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return m, nil
+}
+
+/*
+ * GtkApplicationWindow
+ */
+
+// ApplicationWindow is a representation of GTK's GtkApplicationWindow.
+type ApplicationWindow struct {
+	Window
+
+	// Interfaces
+	gio.ActionMap
+}
+
+var applicationWindowType = glib.Type(C.gtk_application_window_get_type())
+
+func GetApplicationWindowType() glib.Type {
+	return applicationWindowType
+}
+
+// Native() returns a pointer to the underlying GtkApplicationWindow.
+func (v *ApplicationWindow) Native() *C.GtkApplicationWindow {
+	if v == nil {
+		return nil
+	}
+	if warn := v.Typecheck(applicationWindowType); warn != nil {
+		fmt.Fprintln(os.Stderr, warn)
+	}
+	return (*C.GtkApplicationWindow)(v.Ptr())
+}
+
+func wrapApplicationWindow(obj *glib.Object) (w ApplicationWindow) {
+	w.ActionMap = gio.ActionMap{Object: obj}
+	w.Window = wrapWindow(obj)
+	return
+}
+
+// ApplicationWindowNew() is a wrapper around gtk_application_window_new().
+func ApplicationWindowNew(app *Application) (*ApplicationWindow, error) {
+	c := C.gtk_application_window_new(app.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	w := wrapApplicationWindow(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &w, nil
+}
+
+// SetShowMenubar() is a wrapper around
+// gtk_application_window_set_show_menubar().
+func (v *ApplicationWindow) SetShowMenubar(showMenubar bool) {
+	C.gtk_application_window_set_show_menubar(v.Native(),
+		gbool(showMenubar))
+}
+
+// GetShowMenubar() is a wrapper around
+// gtk_application_window_get_show_menubar().
+func (v *ApplicationWindow) GetShowMenubar() bool {
+	c := C.gtk_application_window_get_show_menubar(v.Native())
+	return gobool(c)
+}
+
+// GetId() is a wrapper around gtk_application_window_get_id().
+func (v *ApplicationWindow) GetId() uint {
+	c := C.gtk_application_window_get_id(v.Native())
+	return uint(c)
+}
+
 /*
  * GtkBin
  */
@@ -664,6 +1052,8 @@ func (b *Builder) AddFromString(str string) error {
 //       // not a *gtk.Window
 //   }
 //
+// Menus defined with <menu> elements are returned as a *gio.Menu, which
+// may be passed to functions such as MenuBarNewFromModel().
 func (b *Builder) GetObject(name string) (glib.IObject, error) {
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
@@ -1349,6 +1739,79 @@ func (v *Container) Remove(w IWidget) {
 	C.gtk_container_remove(v.Native(), w.toWidget())
 }
 
+// GetChildren() is a wrapper around gtk_container_get_children().  Each
+// child is returned as the Go type matching its class, falling back to a
+// *Widget for classes without a binding in this package.
+func (v *Container) GetChildren() []glib.IObject {
+	c := C.gtk_container_get_children(v.Native())
+	return widgetList(unsafe.Pointer(c), glib.TRANSFER_CONTAINER)
+}
+
+// Foreach() is a wrapper around gtk_container_foreach().  f is called
+// with each non-internal child, typed as by GetChildren().
+func (v *Container) Foreach(f func(child glib.IObject)) {
+	closure := glib.ClosureNew(func(obj *glib.Object) {
+		f(castWidget((*C.GtkWidget)(obj.Ptr())))
+	})
+	C._gtk_container_foreach(v.Native(),
+		(*C.GClosure)(unsafe.Pointer(closure)))
+}
+
+// FindWidget() walks the widget tree rooted at root depth-first, starting
+// with root itself, and returns the first widget for which pred returns
+// true.  The widget is returned as the Go type matching its class, as by
+// Container.GetChildren().  A non-nil error is returned if no widget
+// matches.
+func FindWidget(root IWidget, pred func(*Widget) bool) (glib.IObject, error) {
+	c := findWidget(root.toWidget(), pred)
+	if c == nil {
+		return nil, errors.New("no matching widget found")
+	}
+	return castWidget(c), nil
+}
+
+// FindWidgetByName() returns the first widget in the tree rooted at root
+// whose name, as set by Widget.SetName() or a Builder file, is name.
+func FindWidgetByName(root IWidget, name string) (glib.IObject, error) {
+	return FindWidget(root, func(w *Widget) bool {
+		n, err := w.Name()
+		return err == nil && n == name
+	})
+}
+
+// FindWidgetByType() returns the first widget in the tree rooted at root
+// which is an instance of t, such as GetEntryType().
+func FindWidgetByType(root IWidget, t glib.Type) (glib.IObject, error) {
+	return FindWidget(root, func(w *Widget) bool {
+		return w.IsA(t)
+	})
+}
+
+func findWidget(c *C.GtkWidget, pred func(*Widget) bool) *C.GtkWidget {
+	if c == nil {
+		return nil
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	w := wrapWidget(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	if pred(&w) {
+		return c
+	}
+	if !w.IsA(containerType) {
+		return nil
+	}
+	list := C.gtk_container_get_children((*C.GtkContainer)(unsafe.Pointer(c)))
+	children := glib.ListToSlice(unsafe.Pointer(list),
+		glib.TRANSFER_CONTAINER)
+	for _, child := range children {
+		if found := findWidget((*C.GtkWidget)(child), pred); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 /*
  * GtkDialog
  */
@@ -1853,11 +2316,11 @@ func (v *Entry) SetIconFromIconName(iconPos EntryIconPosition, name string) {
 		C.GtkEntryIconPosition(iconPos), (*C.gchar)(cstr))
 }
 
-// TODO(jrick) GIcon
-/*
-func (v *Entry) SetIconFromGIcon() {
+// SetIconFromGIcon() is a wrapper around gtk_entry_set_icon_from_gicon().
+func (v *Entry) SetIconFromGIcon(iconPos EntryIconPosition, icon gio.IIcon) {
+	C.gtk_entry_set_icon_from_gicon(v.Native(),
+		C.GtkEntryIconPosition(iconPos), gicon(icon))
 }
-*/
 
 // IconStorageType() is a wrapper around gtk_entry_get_icon_storage_type().
 func (v *Entry) GetIconStorageType(iconPos EntryIconPosition) ImageType {
@@ -1892,11 +2355,15 @@ func (v *Entry) GetIconName(iconPos EntryIconPosition) (string, error) {
 	return C.GoString((*C.char)(c)), nil
 }
 
-// TODO(jrick) GIcon
-/*
-func (v *Entry) IconGIcon() {
+// GetIconGIcon() is a wrapper around gtk_entry_get_icon_gicon().
+func (v *Entry) GetIconGIcon(iconPos EntryIconPosition) (*gio.Icon, error) {
+	c := C.gtk_entry_get_icon_gicon(v.Native(),
+		C.GtkEntryIconPosition(iconPos))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	return goIcon(c), nil
 }
-*/
 
 // SetIconActivatable() is a wrapper around gtk_entry_set_icon_activatable().
 func (v *Entry) SetIconActivatable(iconPos EntryIconPosition, activatable bool) {
@@ -2195,6 +2662,56 @@ func (f *FileChooser) Filename() string {
 	return str
 }
 
+// GetFile() is a wrapper around gtk_file_chooser_get_file().
+func (f *FileChooser) GetFile() (*gio.File, error) {
+	c := C.gtk_file_chooser_get_file(f.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	file := &gio.File{Object: obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return file, nil
+}
+
+// GetFiles() is a wrapper around gtk_file_chooser_get_files().
+func (f *FileChooser) GetFiles() []*gio.File {
+	c := C.gtk_file_chooser_get_files(f.Native())
+	s := glib.SListToSlice(unsafe.Pointer(c), glib.TRANSFER_FULL)
+	files := make([]*gio.File, len(s))
+	for i, p := range s {
+		obj := glib.ObjectNew(p)
+		runtime.SetFinalizer(obj, (*glib.Object).Unref)
+		files[i] = &gio.File{Object: obj}
+	}
+	return files
+}
+
+// SetFile() is a wrapper around gtk_file_chooser_set_file().
+func (f *FileChooser) SetFile(file *gio.File) error {
+	var err *C.GError = nil
+	c := C.gtk_file_chooser_set_file(f.Native(),
+		(*C.GFile)(unsafe.Pointer(file.Native())), &err)
+	if !gobool(c) {
+		defer C.g_error_free(err)
+		return errors.New(C.GoString((*C.char)(C.error_get_message(err))))
+	}
+	return nil
+}
+
+// GetCurrentFolderFile() is a wrapper around
+// gtk_file_chooser_get_current_folder_file().
+func (f *FileChooser) GetCurrentFolderFile() (*gio.File, error) {
+	c := C.gtk_file_chooser_get_current_folder_file(f.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	file := &gio.File{Object: obj}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return file, nil
+}
+
 /*
  * GtkFileChooserButton
  */
@@ -2228,6 +2745,155 @@ func wrapFileChooserButton(obj *glib.Object) (b FileChooserButton) {
 	return
 }
 
+/*
+ * GtkFlowBox
+ */
+
+// FlowBox is a representation of GTK's GtkFlowBox.
+type FlowBox struct {
+	Container
+}
+
+var flowBoxType = glib.Type(C.gtk_flow_box_get_type())
+
+func GetFlowBoxType() glib.Type {
+	return glib.Type(flowBoxType)
+}
+
+// Native() returns a pointer to the underlying GtkFlowBox.
+func (v *FlowBox) Native() *C.GtkFlowBox {
+	if v == nil {
+		return nil
+	}
+	if warn := v.Typecheck(flowBoxType); warn != nil {
+		fmt.Fprintln(os.Stderr, warn)
+	}
+	return (*C.GtkFlowBox)(v.Ptr())
+}
+
+func wrapFlowBox(obj *glib.Object) (b FlowBox) {
+	b.Container = wrapContainer(obj)
+	return
+}
+
+// FlowBoxNew() is a wrapper around gtk_flow_box_new().
+func FlowBoxNew() (*FlowBox, error) {
+	c := C.gtk_flow_box_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	b := wrapFlowBox(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &b, nil
+}
+
+// Insert() is a wrapper around gtk_flow_box_insert().  A position of -1
+// appends the child.
+func (v *FlowBox) Insert(widget IWidget, position int) {
+	C.gtk_flow_box_insert(v.Native(), widget.toWidget(), C.gint(position))
+}
+
+// GetChildAtIndex() is a wrapper around gtk_flow_box_get_child_at_index().
+func (v *FlowBox) GetChildAtIndex(idx int) (*FlowBoxChild, error) {
+	c := C.gtk_flow_box_get_child_at_index(v.Native(), C.gint(idx))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	child := wrapFlowBoxChild(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &child, nil
+}
+
+// SetSelectionMode() is a wrapper around gtk_flow_box_set_selection_mode().
+func (v *FlowBox) SetSelectionMode(mode SelectionMode) {
+	C.gtk_flow_box_set_selection_mode(v.Native(), C.GtkSelectionMode(mode))
+}
+
+// GetSelectionMode() is a wrapper around gtk_flow_box_get_selection_mode().
+func (v *FlowBox) GetSelectionMode() SelectionMode {
+	c := C.gtk_flow_box_get_selection_mode(v.Native())
+	return SelectionMode(c)
+}
+
+// SetHomogeneous() is a wrapper around gtk_flow_box_set_homogeneous().
+func (v *FlowBox) SetHomogeneous(homogeneous bool) {
+	C.gtk_flow_box_set_homogeneous(v.Native(), gbool(homogeneous))
+}
+
+// SetMaxChildrenPerLine() is a wrapper around
+// gtk_flow_box_set_max_children_per_line().
+func (v *FlowBox) SetMaxChildrenPerLine(nChildren uint) {
+	C.gtk_flow_box_set_max_children_per_line(v.Native(), C.guint(nChildren))
+}
+
+// BindModel() is a wrapper around gtk_flow_box_bind_model().  The box's
+// children are kept in sync with the items of model, with
+// createWidget called to create the child for each item.  A nil model
+// unbinds any previously bound model.
+func (v *FlowBox) BindModel(model gio.IListModel, createWidget func(item *glib.Object) IWidget) {
+	C._gtk_flow_box_bind_model(v.Native(), listModel(model),
+		createWidgetClosure(createWidget))
+}
+
+/*
+ * GtkFlowBoxChild
+ */
+
+// FlowBoxChild is a representation of GTK's GtkFlowBoxChild.
+type FlowBoxChild struct {
+	Bin
+}
+
+var flowBoxChildType = glib.Type(C.gtk_flow_box_child_get_type())
+
+func GetFlowBoxChildType() glib.Type {
+	return glib.Type(flowBoxChildType)
+}
+
+// Native() returns a pointer to the underlying GtkFlowBoxChild.
+func (v *FlowBoxChild) Native() *C.GtkFlowBoxChild {
+	if v == nil {
+		return nil
+	}
+	if warn := v.Typecheck(flowBoxChildType); warn != nil {
+		fmt.Fprintln(os.Stderr, warn)
+	}
+	return (*C.GtkFlowBoxChild)(v.Ptr())
+}
+
+func wrapFlowBoxChild(obj *glib.Object) (c FlowBoxChild) {
+	c.Bin = wrapBin(obj)
+	return
+}
+
+// FlowBoxChildNew() is a wrapper around gtk_flow_box_child_new().
+func FlowBoxChildNew() (*FlowBoxChild, error) {
+	c := C.gtk_flow_box_child_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	child := wrapFlowBoxChild(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &child, nil
+}
+
+// GetIndex() is a wrapper around gtk_flow_box_child_get_index().
+func (v *FlowBoxChild) GetIndex() int {
+	c := C.gtk_flow_box_child_get_index(v.Native())
+	return int(c)
+}
+
+// Changed() is a wrapper around gtk_flow_box_child_changed().
+func (v *FlowBoxChild) Changed() {
+	C.gtk_flow_box_child_changed(v.Native())
+}
+
 /*
  * GtkGrid
  */
@@ -2492,11 +3158,18 @@ func ImageNewFromIconName(iconName string, size IconSize) (*Image, error) {
 	return &i, nil
 }
 
-// TODO(jrick) GIcon
-/*
-func ImageNewFromGIcon() {
+// ImageNewFromGIcon() is a wrapper around gtk_image_new_from_gicon().
+func ImageNewFromGIcon(icon gio.IIcon, size IconSize) (*Image, error) {
+	c := C.gtk_image_new_from_gicon(gicon(icon), C.GtkIconSize(size))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	i := wrapImage(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &i, nil
 }
-*/
 
 // Clear() is a wrapper around gtk_image_clear().
 func (v *Image) Clear() {
@@ -2551,11 +3224,10 @@ func (v *Image) SetFromIconName(iconName string, size IconSize) {
 		C.GtkIconSize(size))
 }
 
-// TODO(jrick) GIcon
-/*
-func (v *Image) SetFromGIcon() {
+// SetFromGIcon() is a wrapper around gtk_image_set_from_gicon().
+func (v *Image) SetFromGIcon(icon gio.IIcon, size IconSize) {
+	C.gtk_image_set_from_gicon(v.Native(), gicon(icon), C.GtkIconSize(size))
 }
-*/
 
 // SetPixelSize() is a wrapper around gtk_image_set_pixel_size().
 func (v *Image) SetPixelSize(pixelSize int) {
@@ -2594,11 +3266,17 @@ func (v *Image) IconName() (string, IconSize) {
 	return C.GoString((*C.char)(iconName)), IconSize(size)
 }
 
-// TODO(jrick) GIcon
-/*
-func (v *Image) GIcon() {
+// GIcon() is a wrapper around gtk_image_get_gicon().  A nil icon is
+// returned if the image is not displaying a GIcon.
+func (v *Image) GIcon() (*gio.Icon, IconSize) {
+	var icon *C.GIcon
+	var size C.GtkIconSize
+	C.gtk_image_get_gicon(v.Native(), &icon, &size)
+	if icon == nil {
+		return nil, IconSize(size)
+	}
+	return goIcon(icon), IconSize(size)
 }
-*/
 
 // PixelSize() is a wrapper around gtk_image_get_pixel_size().
 func (v *Image) PixelSize() int {
@@ -2769,6 +3447,167 @@ func (v *Label) SetLabel(str string) {
 	C.gtk_label_set_label(v.Native(), (*C.gchar)(cstr))
 }
 
+/*
+ * GtkListBox
+ */
+
+// ListBox is a representation of GTK's GtkListBox.
+type ListBox struct {
+	Container
+}
+
+var listBoxType = glib.Type(C.gtk_list_box_get_type())
+
+func GetListBoxType() glib.Type {
+	return glib.Type(listBoxType)
+}
+
+// Native() returns a pointer to the underlying GtkListBox.
+func (v *ListBox) Native() *C.GtkListBox {
+	if v == nil {
+		return nil
+	}
+	if warn := v.Typecheck(listBoxType); warn != nil {
+		fmt.Fprintln(os.Stderr, warn)
+	}
+	return (*C.GtkListBox)(v.Ptr())
+}
+
+func wrapListBox(obj *glib.Object) (b ListBox) {
+	b.Container = wrapContainer(obj)
+	return
+}
+
+// ListBoxNew() is a wrapper around gtk_list_box_new().
+func ListBoxNew() (*ListBox, error) {
+	c := C.gtk_list_box_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	b := wrapListBox(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &b, nil
+}
+
+// Prepend() is a wrapper around gtk_list_box_prepend().
+func (v *ListBox) Prepend(child IWidget) {
+	C.gtk_list_box_prepend(v.Native(), child.toWidget())
+}
+
+// Insert() is a wrapper around gtk_list_box_insert().  A position of -1
+// appends the child.
+func (v *ListBox) Insert(child IWidget, position int) {
+	C.gtk_list_box_insert(v.Native(), child.toWidget(), C.gint(position))
+}
+
+// GetRowAtIndex() is a wrapper around gtk_list_box_get_row_at_index().
+func (v *ListBox) GetRowAtIndex(index int) (*ListBoxRow, error) {
+	c := C.gtk_list_box_get_row_at_index(v.Native(), C.gint(index))
+	return listBoxRowFromNative(c)
+}
+
+// GetSelectedRow() is a wrapper around gtk_list_box_get_selected_row().
+func (v *ListBox) GetSelectedRow() (*ListBoxRow, error) {
+	c := C.gtk_list_box_get_selected_row(v.Native())
+	return listBoxRowFromNative(c)
+}
+
+// SelectRow() is a wrapper around gtk_list_box_select_row().  A nil row
+// clears the selection.
+func (v *ListBox) SelectRow(row *ListBoxRow) {
+	C.gtk_list_box_select_row(v.Native(), row.Native())
+}
+
+// SetSelectionMode() is a wrapper around gtk_list_box_set_selection_mode().
+func (v *ListBox) SetSelectionMode(mode SelectionMode) {
+	C.gtk_list_box_set_selection_mode(v.Native(), C.GtkSelectionMode(mode))
+}
+
+// GetSelectionMode() is a wrapper around gtk_list_box_get_selection_mode().
+func (v *ListBox) GetSelectionMode() SelectionMode {
+	c := C.gtk_list_box_get_selection_mode(v.Native())
+	return SelectionMode(c)
+}
+
+// SetPlaceholder() is a wrapper around gtk_list_box_set_placeholder().
+func (v *ListBox) SetPlaceholder(placeholder IWidget) {
+	var w *C.GtkWidget
+	if placeholder != nil {
+		w = placeholder.toWidget()
+	}
+	C.gtk_list_box_set_placeholder(v.Native(), w)
+}
+
+// BindModel() is a wrapper around gtk_list_box_bind_model().  The box's
+// rows are kept in sync with the items of model, with createWidget called
+// to create the row for each item.  A nil model unbinds any previously
+// bound model.
+func (v *ListBox) BindModel(model gio.IListModel, createWidget func(item *glib.Object) IWidget) {
+	C._gtk_list_box_bind_model(v.Native(), listModel(model),
+		createWidgetClosure(createWidget))
+}
+
+/*
+ * GtkListBoxRow
+ */
+
+// ListBoxRow is a representation of GTK's GtkListBoxRow.
+type ListBoxRow struct {
+	Bin
+}
+
+var listBoxRowType = glib.Type(C.gtk_list_box_row_get_type())
+
+func GetListBoxRowType() glib.Type {
+	return glib.Type(listBoxRowType)
+}
+
+// Native() returns a pointer to the underlying GtkListBoxRow.
+func (v *ListBoxRow) Native() *C.GtkListBoxRow {
+	if v == nil {
+		return nil
+	}
+	if warn := v.Typecheck(listBoxRowType); warn != nil {
+		fmt.Fprintln(os.Stderr, warn)
+	}
+	return (*C.GtkListBoxRow)(v.Ptr())
+}
+
+func wrapListBoxRow(obj *glib.Object) (r ListBoxRow) {
+	r.Bin = wrapBin(obj)
+	return
+}
+
+func listBoxRowFromNative(c *C.GtkListBoxRow) (*ListBoxRow, error) {
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	r := wrapListBoxRow(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &r, nil
+}
+
+// ListBoxRowNew() is a wrapper around gtk_list_box_row_new().
+func ListBoxRowNew() (*ListBoxRow, error) {
+	c := C.gtk_list_box_row_new()
+	return listBoxRowFromNative((*C.GtkListBoxRow)(unsafe.Pointer(c)))
+}
+
+// GetIndex() is a wrapper around gtk_list_box_row_get_index().
+func (v *ListBoxRow) GetIndex() int {
+	c := C.gtk_list_box_row_get_index(v.Native())
+	return int(c)
+}
+
+// Changed() is a wrapper around gtk_list_box_row_changed().
+func (v *ListBoxRow) Changed() {
+	C.gtk_list_box_row_changed(v.Native())
+}
+
 /*
  * GtkListStore
  */
@@ -2982,6 +3821,19 @@ func wrapMenu(obj *glib.Object) (m Menu) {
 	return
 }
 
+// MenuNewFromModel() is a wrapper around gtk_menu_new_from_model().
+func MenuNewFromModel(model gio.IMenuModel) (*Menu, error) {
+	c := C.gtk_menu_new_from_model(menuModel(model))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapMenu(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &m, nil
+}
+
 // MenuNew() is a wrapper around gtk_menu_new().
 func MenuNew() (*Menu, error) {
 	c := C.gtk_menu_new()
@@ -3026,6 +3878,19 @@ func wrapMenuBar(obj *glib.Object) (m MenuBar) {
 	return
 }
 
+// MenuBarNewFromModel() is a wrapper around gtk_menu_bar_new_from_model().
+func MenuBarNewFromModel(model gio.IMenuModel) (*MenuBar, error) {
+	c := C.gtk_menu_bar_new_from_model(menuModel(model))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	m := wrapMenuBar(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &m, nil
+}
+
 // MenuBarNew() is a wrapper around gtk_menu_bar_new().
 func MenuBarNew() (*MenuBar, error) {
 	c := C.gtk_menu_bar_new()
@@ -4638,14 +5503,29 @@ func (v *Widget) SetAccelPath() {
 }
 */
 
-// TODO(jrick) GList
-/*
-func (v *Widget) ListAccelClosures() {
+// ListAccelClosures() is a wrapper around gtk_widget_list_accel_closures().
+// Each element points to a GClosure owned by the widget.
+func (v *Widget) ListAccelClosures() []unsafe.Pointer {
+	c := C.gtk_widget_list_accel_closures(v.Native())
+	return glib.ListToSlice(unsafe.Pointer(c), glib.TRANSFER_CONTAINER)
 }
-*/
 
 //gboolean gtk_widget_can_activate_accel(GtkWidget *widget, guint signal_id);
 
+// InsertActionGroup() is a wrapper around gtk_widget_insert_action_group().
+// The actions in group become available to the widget and its children
+// under the given prefix, such as "win".  Passing a nil group removes the
+// group previously inserted with prefix.
+func (v *Widget) InsertActionGroup(prefix string, group gio.IActionGroup) {
+	cstr := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cstr))
+	var g *C.GActionGroup = nil
+	if group != nil {
+		g = (*C.GActionGroup)(group.ToObject().Ptr())
+	}
+	C.gtk_widget_insert_action_group(v.Native(), (*C.gchar)(cstr), g)
+}
+
 // Event() is a wrapper around gtk_widget_event().
 func (v *Widget) Event(event *gdk.Event) bool {
 	c := C.gtk_widget_event(v.Native(),
@@ -4980,6 +5860,31 @@ func (v *Window) Gravity() {
 }
 */
 
+// WindowListToplevels() is a wrapper around gtk_window_list_toplevels().
+// Each window is returned as the Go type matching its class.
+func WindowListToplevels() []glib.IObject {
+	c := C.gtk_window_list_toplevels()
+	return widgetList(unsafe.Pointer(c), glib.TRANSFER_CONTAINER)
+}
+
+// SetApplication() is a wrapper around gtk_window_set_application().
+func (v *Window) SetApplication(app *Application) {
+	C.gtk_window_set_application(v.Native(), app.Native())
+}
+
+// GetApplication() is a wrapper around gtk_window_get_application().
+func (v *Window) GetApplication() (*Application, error) {
+	c := C.gtk_window_get_application(v.Native())
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	a := wrapApplication(obj)
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &a, nil
+}
+
 // SetPosition() is a wrapper around gtk_window_set_position()
 func (v *Window) SetPosition(position WindowPosition) {
 	C.gtk_window_set_position(v.Native(), C.GtkWindowPosition(position))
@@ -4994,17 +5899,60 @@ func (v *Window) SetTransientFor(parent IWindow) {
 	C.gtk_window_set_transient_for(v.Native(), pw)
 }
 
+// CastObject() returns obj as the Go type matching its GObject class, as
+// done by Builder.GetObject().  The result holds its own reference to the
+// underlying GObject.  An error is returned for classes unknown to this
+// package.
+func CastObject(obj glib.IObject) (glib.IObject, error) {
+	return cast((*C.GObject)(obj.ToObject().Ptr()))
+}
+
+// castWidget() casts a native GtkWidget to the appropriate Go struct as
+// cast() does, falling back to a *Widget for unrecognized classes.
+func castWidget(c *C.GtkWidget) glib.IObject {
+	if obj, err := cast((*C.GObject)(unsafe.Pointer(c))); err == nil {
+		return obj
+	}
+	obj := glib.ObjectNew(unsafe.Pointer(c))
+	w := wrapWidget(obj)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return &w
+}
+
+// widgetList() converts a GList of GtkWidgets to a slice holding each
+// widget as the Go type matching its class.
+func widgetList(list unsafe.Pointer, transfer glib.Transfer) []glib.IObject {
+	s := glib.ListToSlice(list, transfer)
+	widgets := make([]glib.IObject, len(s))
+	for i, p := range s {
+		widgets[i] = castWidget((*C.GtkWidget)(p))
+	}
+	return widgets
+}
+
 // cast() takes a native GObject and casts it to the appropriate Go struct.
+// A reference is added for Go, sinking the floating reference if
+// necessary.
 func cast(c *C.GObject) (glib.IObject, error) {
 	var (
 		className = C.GoString((*C.char)(C.object_get_class_name(c)))
 		obj       = glib.ObjectNew(unsafe.Pointer(c))
 	)
+	obj.RefSink()
 	runtime.SetFinalizer(obj, (*glib.Object).Unref)
 	switch className {
 	case "GtkAdjustment":
 		a := wrapAdjustment(obj)
 		return &a, nil
+	case "GMenu":
+		return &gio.Menu{MenuModel: gio.MenuModel{Object: obj}}, nil
+	case "GtkApplication":
+		a := wrapApplication(obj)
+		return &a, nil
+	case "GtkApplicationWindow":
+		a := wrapApplicationWindow(obj)
+		return &a, nil
 	case "GtkBin":
 		b := wrapBin(obj)
 		return &b, nil
@@ -5044,6 +5992,12 @@ func cast(c *C.GObject) (glib.IObject, error) {
 	case "GtkFileChooserButton":
 		f := wrapFileChooserButton(obj)
 		return &f, nil
+	case "GtkFlowBox":
+		b := wrapFlowBox(obj)
+		return &b, nil
+	case "GtkFlowBoxChild":
+		c := wrapFlowBoxChild(obj)
+		return &c, nil
 	case "GtkGrid":
 		g := wrapGrid(obj)
 		return &g, nil
@@ -5056,6 +6010,12 @@ func cast(c *C.GObject) (glib.IObject, error) {
 	case "GtkLabel":
 		l := wrapLabel(obj)
 		return &l, nil
+	case "GtkListBox":
+		b := wrapListBox(obj)
+		return &b, nil
+	case "GtkListBoxRow":
+		r := wrapListBoxRow(obj)
+		return &r, nil
 	case "GtkListStore":
 		l := wrapListStore(obj)
 		return &l, nil
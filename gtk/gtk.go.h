@@ -60,3 +60,75 @@ object_get_class_name(GObject *object)
 {
 	return G_OBJECT_CLASS_NAME(G_OBJECT_GET_CLASS(object));
 }
+
+/*
+ * Callbacks
+ */
+
+static void
+_gtk_callback_closure(GtkWidget *widget, gpointer data)
+{
+	GValue		 val = G_VALUE_INIT;
+
+	g_value_init(&val, G_TYPE_OBJECT);
+	g_value_set_object(&val, widget);
+	g_closure_invoke((GClosure *)data, NULL, 1, &val, NULL);
+	g_value_unset(&val);
+}
+
+static void
+_gtk_container_foreach(GtkContainer *container, GClosure *closure)
+{
+	g_closure_ref(closure);
+	g_closure_sink(closure);
+	gtk_container_foreach(container, _gtk_callback_closure, closure);
+	g_closure_unref(closure);
+}
+
+static GtkWidget *
+_gtk_create_widget_closure(gpointer item, gpointer data)
+{
+	GValue		 arg = G_VALUE_INIT;
+	GValue		 ret = G_VALUE_INIT;
+	GtkWidget	*widget;
+
+	g_value_init(&arg, G_TYPE_OBJECT);
+	g_value_set_object(&arg, item);
+	g_value_init(&ret, G_TYPE_OBJECT);
+	g_closure_invoke((GClosure *)data, &ret, 1, &arg, NULL);
+	/* The returned reference is owned by the box. */
+	widget = g_value_dup_object(&ret);
+	g_value_unset(&arg);
+	g_value_unset(&ret);
+	return widget;
+}
+
+static void
+_gtk_list_box_bind_model(GtkListBox *box, GListModel *model,
+    GClosure *closure)
+{
+	if (closure == NULL) {
+		gtk_list_box_bind_model(box, model, NULL, NULL, NULL);
+		return;
+	}
+	g_closure_ref(closure);
+	g_closure_sink(closure);
+	gtk_list_box_bind_model(box, model,
+	    (GtkListBoxCreateWidgetFunc)_gtk_create_widget_closure, closure,
+	    (GDestroyNotify)g_closure_unref);
+}
+
+static void
+_gtk_flow_box_bind_model(GtkFlowBox *box, GListModel *model,
+    GClosure *closure)
+{
+	if (closure == NULL) {
+		gtk_flow_box_bind_model(box, model, NULL, NULL, NULL);
+		return;
+	}
+	g_closure_ref(closure);
+	g_closure_sink(closure);
+	gtk_flow_box_bind_model(box, model,
+	    (GtkFlowBoxCreateWidgetFunc)_gtk_create_widget_closure, closure,
+	    (GDestroyNotify)g_closure_unref);
+}
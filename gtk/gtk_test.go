@@ -19,9 +19,27 @@
 package gtk
 
 import (
+	"github.com/dradtke/gotk3/gio"
+	"github.com/dradtke/gotk3/glib"
+	"sync"
 	"testing"
 )
 
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+// requireDisplay initializes GTK, skipping t if no display is available.
+func requireDisplay(t *testing.T) {
+	initOnce.Do(func() {
+		initErr = InitCheck(nil)
+	})
+	if initErr != nil {
+		t.Skip("no display available:", initErr)
+	}
+}
+
 // TestBoolConvs tests the conversion between Go bools and gboolean
 // types.
 func TestBoolConvs(t *testing.T) {
@@ -71,3 +89,51 @@ func TestBox(t *testing.T) {
 	vbox.PackStart(start, true, true, 3)
 	vbox.PackEnd(end, true, true, 3)
 }
+
+// TestListBoxBindModel tests that a ListBox bound to a ListStore creates
+// a row for each item and tracks changes to the store.
+func TestListBoxBindModel(t *testing.T) {
+	requireDisplay(t)
+	box, err := ListBoxNew()
+	if err != nil {
+		t.Fatal("Unable to create list box")
+	}
+	store, err := gio.ListStoreNew(GetLabelType())
+	if err != nil {
+		t.Fatal("Unable to create list store")
+	}
+	for _, text := range []string{"one", "two"} {
+		label, err := LabelNew(text)
+		if err != nil {
+			t.Fatal("Unable to create label")
+		}
+		store.Append(label)
+	}
+
+	box.BindModel(store, func(item *glib.Object) IWidget {
+		label := wrapLabel(item)
+		text, _ := label.Text()
+		l, _ := LabelNew(text)
+		return l
+	})
+	if n := len(box.GetChildren()); n != 2 {
+		t.Errorf("Expected 2 rows, got %d", n)
+	}
+
+	store.Remove(0)
+	if n := len(box.GetChildren()); n != 1 {
+		t.Errorf("Expected 1 row after removal, got %d", n)
+	}
+	row, err := box.GetRowAtIndex(0)
+	if err != nil {
+		t.Fatal("Unable to get row")
+	}
+	child, err := row.Child()
+	if err != nil {
+		t.Fatal("Unable to get row child")
+	}
+	label := wrapLabel(child.ToObject())
+	if text, _ := label.Text(); text != "two" {
+		t.Errorf("Expected remaining row %q, got %q", "two", text)
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Package gtktest provides helpers for testing GTK+ user interfaces built
+with the gtk package, including on machines without a display.
+
+Tests call Init() before using any GTK functions.  If no display is
+available, Init() attempts to start a virtual X server (Xvfb) or a
+Broadway server (broadwayd), and skips the test if neither can be
+found.  A test binary should also use Main() from its TestMain function
+so that any server started by Init() is stopped once the tests finish:
+
+	func TestMain(m *testing.M) {
+		gtktest.Main(m)
+	}
+
+GTK is not thread safe, so tests using this package must not be run in
+parallel.  Widgets only receive synthesized events once they are
+realized, which usually means that their toplevel window has been shown
+and the main loop pumped with Pump().
+*/
+package gtktest
+
+// #cgo pkg-config: gtk+-3.0
+// #include <gtk/gtk.h>
+// #include "gtktest.go.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"github.com/dradtke/gotk3/gdk"
+	"github.com/dradtke/gotk3/glib"
+	"github.com/dradtke/gotk3/gtk"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+ * Unexported vars
+ */
+
+var (
+	initOnce sync.Once
+	initErr  error
+	server   *exec.Cmd
+
+	notRealizedErr = errors.New("widget is not realized")
+	notWidgetErr   = errors.New("not a GtkWidget")
+)
+
+/*
+ * Initialization
+ */
+
+// Main() runs the tests in m, stops any display server started by Init(),
+// and exits with the result of the tests.  It is meant to be called from
+// a test binary's TestMain function.
+func Main(m *testing.M) {
+	code := m.Run()
+	if server != nil {
+		server.Process.Kill()
+		server.Wait()
+	}
+	os.Exit(code)
+}
+
+// Init() initializes GTK for testing, skipping t if no display is available
+// and none could be started.  It is safe to call Init from every test;
+// GTK is only initialized once per test binary.
+func Init(t testing.TB) {
+	initOnce.Do(func() {
+		initErr = initDisplay()
+	})
+	if initErr != nil {
+		t.Skip("gtktest: no display available:", initErr)
+	}
+}
+
+// initDisplay() initializes GTK against the display named by the
+// environment, or else against a newly started Xvfb or broadwayd.
+func initDisplay() error {
+	if os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != "" ||
+		os.Getenv("BROADWAY_DISPLAY") != "" {
+		return gtk.InitCheck(nil)
+	}
+	if path, err := exec.LookPath("Xvfb"); err == nil {
+		if err := startXvfb(path); err == nil {
+			return gtk.InitCheck(nil)
+		}
+	}
+	if path, err := exec.LookPath("broadwayd"); err == nil {
+		if err := startBroadway(path); err == nil {
+			return gtk.InitCheck(nil)
+		}
+	}
+	return errors.New("DISPLAY is unset and neither Xvfb nor broadwayd could be started")
+}
+
+// startXvfb() starts an Xvfb server on the first free display number and
+// waits for it to accept connections.
+func startXvfb(path string) error {
+	for n := 99; n < 199; n++ {
+		if _, err := os.Stat(fmt.Sprintf("/tmp/.X%d-lock", n)); err == nil {
+			continue
+		}
+		display := fmt.Sprintf(":%d", n)
+		cmd := exec.Command(path, display, "-screen", "0",
+			"1280x1024x24", "-nolisten", "tcp")
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		socket := fmt.Sprintf("/tmp/.X11-unix/X%d", n)
+		if waitFor("unix", socket) {
+			server = cmd
+			os.Setenv("DISPLAY", display)
+			return nil
+		}
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+	return errors.New("unable to start Xvfb")
+}
+
+// startBroadway() starts a broadwayd server and waits for it to accept
+// connections.
+func startBroadway(path string) error {
+	for n := 5; n < 50; n++ {
+		display := fmt.Sprintf(":%d", n)
+		cmd := exec.Command(path, display)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		if waitFor("tcp", fmt.Sprintf("127.0.0.1:%d", 8080+n)) {
+			server = cmd
+			os.Setenv("GDK_BACKEND", "broadway")
+			os.Setenv("BROADWAY_DISPLAY", display)
+			return nil
+		}
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+	return errors.New("unable to start broadwayd")
+}
+
+// waitFor() polls until a connection to address can be made, giving up
+// after five seconds.
+func waitFor(network, address string) bool {
+	for i := 0; i < 50; i++ {
+		if c, err := net.Dial(network, address); err == nil {
+			c.Close()
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
+
+/*
+ * Main loop
+ */
+
+// Pump() runs iterations of the GTK main loop until no events or idle
+// sources are pending.
+func Pump() {
+	for gtk.EventsPending() {
+		gtk.MainIterationDo(false)
+	}
+}
+
+// PumpUntil() runs iterations of the GTK main loop until cond returns true
+// or timeout elapses, and returns the last result of cond.
+func PumpUntil(cond func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		if !gtk.EventsPending() {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		gtk.MainIterationDo(false)
+	}
+	return true
+}
+
+/*
+ * Event synthesis
+ */
+
+// widget() returns a pointer to the GtkWidget underlying w, or an error
+// if w is not backed by a GtkWidget.
+func widget(w gtk.IWidget) (*C.GtkWidget, error) {
+	obj, ok := w.(glib.IObject)
+	if !ok {
+		return nil, notWidgetErr
+	}
+	o := obj.ToObject()
+	if o == nil || o.Ptr() == nil || !o.IsA(gtk.GetWidgetType()) {
+		return nil, notWidgetErr
+	}
+	return (*C.GtkWidget)(o.Ptr()), nil
+}
+
+// SendKey() focuses w and sends it a key press and release for keyval,
+// with the modifiers in state held down.  Keyvals may be looked up by
+// name with gdk.KeyvalFromName().  The events are dispatched
+// synchronously, but handlers which defer work to the main loop may
+// require a call to Pump() before their effects are visible.
+func SendKey(w gtk.IWidget, keyval uint, state gdk.ModifierType) error {
+	cw, err := widget(w)
+	if err != nil {
+		return err
+	}
+	c := C._gtktest_send_key(cw, C.guint(keyval),
+		C.GdkModifierType(state))
+	if c == 0 {
+		return notRealizedErr
+	}
+	return nil
+}
+
+// SendText() calls SendKey() for each character of text.
+func SendText(w gtk.IWidget, text string) error {
+	for _, r := range text {
+		keyval := uint(C.gdk_unicode_to_keyval(C.guint32(r)))
+		if err := SendKey(w, keyval, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Click() sends a press and release of the given mouse button (1 through
+// 5) at the center of w, with the modifiers in state held down.
+func Click(w gtk.IWidget, button uint, state gdk.ModifierType) error {
+	cw, err := widget(w)
+	if err != nil {
+		return err
+	}
+	c := C._gtktest_click(cw, C.guint(button),
+		C.GdkModifierType(state))
+	if c == 0 {
+		return notRealizedErr
+	}
+	return nil
+}
+
+/*
+ * Widget lookup
+ */
+
+// FindByName() returns the first widget in a depth-first search of the
+// widget tree rooted at root whose name, as set by (*gtk.Widget).SetName()
+// or a Builder file, is name.  The result may be type asserted to the
+// widget's gtk type.
+func FindByName(root gtk.IWidget, name string) (glib.IObject, error) {
+	return gtk.FindWidgetByName(root, name)
+}
+
+// FindByType() returns the first widget in a depth-first search of the
+// widget tree rooted at root which is an instance of t, such as
+// gtk.GetEntryType().  The result may be type asserted to the widget's
+// gtk type.
+func FindByType(root gtk.IWidget, t glib.Type) (glib.IObject, error) {
+	return gtk.FindWidgetByType(root, t)
+}
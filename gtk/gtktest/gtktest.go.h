@@ -0,0 +1,144 @@
+#include <stdlib.h>
+
+static GdkDevice *
+_gtktest_client_pointer(GdkWindow *window)
+{
+	GdkDeviceManager	*dm;
+
+	dm = gdk_display_get_device_manager(gdk_window_get_display(window));
+	return (gdk_device_manager_get_client_pointer(dm));
+}
+
+/*
+ * Returns the GdkWindow which receives pointer events for widget, and the
+ * coordinates of the widget's center relative to that window.
+ */
+static GdkWindow *
+_gtktest_event_window(GtkWidget *widget, gint *x, gint *y)
+{
+	GtkAllocation	 alloc;
+	GdkWindow	*window;
+	GList		*l;
+	gpointer	 data;
+
+	window = gtk_widget_get_window(widget);
+	for (l = gdk_window_peek_children(window); l != NULL; l = l->next) {
+		gdk_window_get_user_data(l->data, &data);
+		if (data == widget && gdk_window_is_visible(l->data)) {
+			*x = gdk_window_get_width(l->data) / 2;
+			*y = gdk_window_get_height(l->data) / 2;
+			return (l->data);
+		}
+	}
+
+	gtk_widget_get_allocation(widget, &alloc);
+	if (gtk_widget_get_has_window(widget)) {
+		*x = alloc.width / 2;
+		*y = alloc.height / 2;
+	} else {
+		*x = alloc.x + alloc.width / 2;
+		*y = alloc.y + alloc.height / 2;
+	}
+	return (window);
+}
+
+static gboolean
+_gtktest_send_key(GtkWidget *widget, guint keyval, GdkModifierType state)
+{
+	GdkEventType	 types[2] = { GDK_KEY_PRESS, GDK_KEY_RELEASE };
+	GtkWidget	*toplevel;
+	GdkWindow	*window;
+	GdkDevice	*keyboard;
+	GdkKeymapKey	*keys = NULL;
+	GdkEvent	*event;
+	gint		 n_keys = 0;
+	int		 i;
+
+	toplevel = gtk_widget_get_toplevel(widget);
+	window = gtk_widget_get_window(toplevel);
+	if (window == NULL)
+		return (FALSE);
+	keyboard = gdk_device_get_associated_device(
+	    _gtktest_client_pointer(window));
+
+	event = gdk_event_new(GDK_FOCUS_CHANGE);
+	event->focus_change.window = g_object_ref(window);
+	event->focus_change.send_event = TRUE;
+	event->focus_change.in = TRUE;
+	gdk_event_set_device(event, keyboard);
+	gtk_widget_send_focus_change(toplevel, event);
+	gdk_event_free(event);
+	gtk_widget_grab_focus(widget);
+
+	gdk_keymap_get_entries_for_keyval(
+	    gdk_keymap_get_for_display(gdk_window_get_display(window)),
+	    keyval, &keys, &n_keys);
+	for (i = 0; i < 2; i++) {
+		event = gdk_event_new(types[i]);
+		event->key.window = g_object_ref(window);
+		event->key.send_event = TRUE;
+		event->key.time = GDK_CURRENT_TIME;
+		event->key.state = state;
+		event->key.keyval = keyval;
+		if (n_keys > 0) {
+			event->key.hardware_keycode = keys[0].keycode;
+			event->key.group = keys[0].group;
+		}
+		gdk_event_set_device(event, keyboard);
+		gtk_main_do_event(event);
+		gdk_event_free(event);
+	}
+	g_free(keys);
+	return (TRUE);
+}
+
+static gboolean
+_gtktest_click(GtkWidget *widget, guint button, GdkModifierType state)
+{
+	GdkEventType	 types[2] = { GDK_BUTTON_PRESS, GDK_BUTTON_RELEASE };
+	GdkWindow	*window;
+	GdkDevice	*pointer;
+	GdkEvent	*event;
+	gint		 x, y, rx, ry;
+	int		 i;
+
+	if (!gtk_widget_get_realized(widget) || button < 1 || button > 5)
+		return (FALSE);
+	window = _gtktest_event_window(widget, &x, &y);
+	pointer = _gtktest_client_pointer(window);
+	gdk_window_get_root_coords(window, x, y, &rx, &ry);
+
+	event = gdk_event_new(GDK_ENTER_NOTIFY);
+	event->crossing.window = g_object_ref(window);
+	event->crossing.send_event = TRUE;
+	event->crossing.time = GDK_CURRENT_TIME;
+	event->crossing.x = x;
+	event->crossing.y = y;
+	event->crossing.x_root = rx;
+	event->crossing.y_root = ry;
+	event->crossing.mode = GDK_CROSSING_NORMAL;
+	event->crossing.detail = GDK_NOTIFY_ANCESTOR;
+	event->crossing.state = state;
+	gdk_event_set_device(event, pointer);
+	gtk_main_do_event(event);
+	gdk_event_free(event);
+
+	for (i = 0; i < 2; i++) {
+		event = gdk_event_new(types[i]);
+		event->button.window = g_object_ref(window);
+		event->button.send_event = TRUE;
+		event->button.time = GDK_CURRENT_TIME;
+		event->button.x = x;
+		event->button.y = y;
+		event->button.x_root = rx;
+		event->button.y_root = ry;
+		event->button.button = button;
+		event->button.state = state;
+		if (types[i] == GDK_BUTTON_RELEASE)
+			event->button.state |= GDK_BUTTON1_MASK << (button - 1);
+		gdk_event_set_device(event, pointer);
+		gtk_main_do_event(event);
+		gdk_event_free(event);
+	}
+	return (TRUE);
+}
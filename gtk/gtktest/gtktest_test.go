@@ -0,0 +1,126 @@
+package gtktest
+
+import (
+	"github.com/dradtke/gotk3/gdk"
+	"github.com/dradtke/gotk3/gtk"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	Main(m)
+}
+
+// testWindow creates and shows a window holding a named entry and button.
+func testWindow(t *testing.T) *gtk.Window {
+	win, err := gtk.WindowNew(gtk.WINDOW_TOPLEVEL)
+	if err != nil {
+		t.Fatal("Unable to create window:", err)
+	}
+	box, err := gtk.BoxNew(gtk.ORIENTATION_VERTICAL, 0)
+	if err != nil {
+		t.Fatal("Unable to create box:", err)
+	}
+	entry, err := gtk.EntryNew()
+	if err != nil {
+		t.Fatal("Unable to create entry:", err)
+	}
+	entry.SetName("username")
+	button, err := gtk.ButtonNewWithLabel("OK")
+	if err != nil {
+		t.Fatal("Unable to create button:", err)
+	}
+	button.SetName("ok")
+	box.PackStart(entry, false, false, 0)
+	box.PackStart(button, false, false, 0)
+	win.Add(box)
+	win.ShowAll()
+	Pump()
+	return win
+}
+
+// TestFind tests finding widgets by name and type.
+func TestFind(t *testing.T) {
+	Init(t)
+	win := testWindow(t)
+	defer win.Destroy()
+
+	obj, err := FindByName(win, "username")
+	if err != nil {
+		t.Fatal("Unable to find entry by name:", err)
+	}
+	if _, ok := obj.(*gtk.Entry); !ok {
+		t.Errorf("Found %T, expected *gtk.Entry", obj)
+	}
+
+	obj, err = FindByType(win, gtk.GetButtonType())
+	if err != nil {
+		t.Fatal("Unable to find button by type:", err)
+	}
+	if _, ok := obj.(*gtk.Button); !ok {
+		t.Errorf("Found %T, expected *gtk.Button", obj)
+	}
+
+	if _, err := FindByName(win, "missing"); err == nil {
+		t.Error("Found a widget that does not exist")
+	}
+}
+
+// TestSendText tests typing into an entry.
+func TestSendText(t *testing.T) {
+	Init(t)
+	win := testWindow(t)
+	defer win.Destroy()
+
+	obj, err := FindByName(win, "username")
+	if err != nil {
+		t.Fatal("Unable to find entry:", err)
+	}
+	entry := obj.(*gtk.Entry)
+	if err := SendText(entry, "gotk3"); err != nil {
+		t.Fatal("Unable to send text:", err)
+	}
+	Pump()
+	if text, _ := entry.Text(); text != "gotk3" {
+		t.Errorf("Entry text is %q, expected %q", text, "gotk3")
+	}
+}
+
+// TestClick tests clicking a button.
+func TestClick(t *testing.T) {
+	Init(t)
+	win := testWindow(t)
+	defer win.Destroy()
+
+	obj, err := FindByName(win, "ok")
+	if err != nil {
+		t.Fatal("Unable to find button:", err)
+	}
+	button := obj.(*gtk.Button)
+	clicked := false
+	button.Connect("clicked", func() {
+		clicked = true
+	})
+	if err := Click(button, 1, gdk.ModifierType(0)); err != nil {
+		t.Fatal("Unable to click button:", err)
+	}
+	Pump()
+	if !clicked {
+		t.Error("Button was not clicked")
+	}
+}
+
+// TestNotWidget tests that event synthesis fails cleanly for an IWidget
+// which is not backed by a GObject.
+func TestNotWidget(t *testing.T) {
+	Init(t)
+	win := testWindow(t)
+	defer win.Destroy()
+
+	w := struct{ gtk.IWidget }{win}
+	if err := SendKey(w, 'a', 0); err == nil {
+		t.Error("SendKey succeeded for a non-widget")
+	}
+	if err := Click(w, 1, 0); err == nil {
+		t.Error("Click succeeded for a non-widget")
+	}
+}